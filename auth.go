@@ -0,0 +1,125 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// Matches the "error" parameter of a WWW-Authenticate header (see RFC 6750).
+var authErrorPattern = regexp.MustCompile(`(?:^|[\s,])error="?([^",\s]+)"?`)
+
+// TokenAuth authenticates HTTP requests using a token.
+// When a request is rejected with a 401 status code, the token is refreshed and the request is replayed once.
+// Concurrent requests which are rejected share a single refresh.
+type TokenAuth struct {
+	Fetch           func() (string, error)                    // Obtains a fresh token.
+	Apply           func(request *http.Request, token string) // Adds token to request. Defaults to a "Bearer" token.
+	ForbiddenErrors []string                                  // The WWW-Authenticate errors which re-authenticate on 403.
+
+	lock       sync.Mutex // Protect concurrent access to the token.
+	token      string     // The current token.
+	generation int        // The number of times a token was fetched.
+}
+
+// Invalidate invalidates the current token of auth, so a fresh one is fetched for the next request.
+func (auth *TokenAuth) Invalidate() {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	auth.token = ""
+}
+
+// Adds the current token to request, fetching one first if there's none.
+// It returns the generation of the token or an error if no token can be fetched.
+func (auth *TokenAuth) apply(request *http.Request) (int, error) {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	if auth.token == "" {
+		if err := auth.fetch(); err != nil {
+			return 0, err
+		}
+	}
+
+	if auth.Apply != nil {
+		auth.Apply(request, auth.token)
+	} else {
+		request.Header.Set("Authorization", "Bearer "+auth.token)
+	}
+
+	return auth.generation, nil
+}
+
+// Fetches a fresh token, unless the token was already refreshed since generation.
+// It returns an error if no token can be fetched.
+func (auth *TokenAuth) refresh(generation int) error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	if auth.generation != generation && auth.token != "" {
+		return nil
+	}
+
+	return auth.fetch()
+}
+
+// Fetches a fresh token. The caller must hold auth.lock.
+// It returns an error if no token can be fetched.
+func (auth *TokenAuth) fetch() error {
+	token, err := auth.Fetch()
+
+	if err != nil {
+		auth.token = ""
+
+		return err
+	}
+
+	auth.token = token
+	auth.generation++
+
+	return nil
+}
+
+// Reports whether response indicates that the token was rejected.
+func (auth *TokenAuth) rejected(response *http.Response) bool {
+	switch response.StatusCode {
+	case http.StatusUnauthorized:
+		return true
+
+	case http.StatusForbidden:
+		for _, header := range response.Header.Values("WWW-Authenticate") {
+			if match := authErrorPattern.FindStringSubmatch(header); match != nil && slices.Contains(auth.ForbiddenErrors, match[1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
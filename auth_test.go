@@ -0,0 +1,247 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// Returns a server which only accepts valid as "Bearer" token and which echoes the body of the request.
+// Rejected requests receive statusCode and the WWW-Authenticate header challenge.
+func newAuthServer(valid string, statusCode int, challenge string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.Header().Set("WWW-Authenticate", challenge)
+			w.WriteHeader(statusCode)
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+}
+
+// Returns a function which returns the tokens "token-1", "token-2", ... and which counts its invocations in count.
+func newTokenFetcher(count *atomic.Int32) func() (string, error) {
+	return func() (string, error) {
+		return fmt.Sprintf("token-%d", count.Add(1)), nil
+	}
+}
+
+// UT: Re-authenticate HTTP requests which are rejected.
+func TestTokenAuth(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the token is rejected.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newAuthServer("token-2", http.StatusUnauthorized, `Bearer error="invalid_token"`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var fetches atomic.Int32
+		var got map[string]string
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches)},
+			},
+			Payload: `{"id":"0"}`,
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the request is replayed with a fresh token.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got["id"], "0", "\n\n"+
+			"UT Name:  The payload is sent again when the request is replayed.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got["id"])
+	})
+
+	t.Run("When the fresh token is rejected as well.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newAuthServer("token-3", http.StatusUnauthorized, `Bearer error="invalid_token"`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var fetches atomic.Int32
+		var got any
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches)},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when the fresh token is rejected.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, fetches.Load(), 2, "\n\n"+
+			"UT Name:  The request is only replayed once.\n"+
+			"\033[32mExpected: 2\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", fetches.Load())
+	})
+
+	t.Run("When the token is forbidden with a configured error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newAuthServer("token-2", http.StatusForbidden, `Bearer realm="api", error="invalid_token"`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var fetches atomic.Int32
+		var got string
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches), ForbiddenErrors: []string{"invalid_token"}},
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the request is replayed with a fresh token.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the token is forbidden with another error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newAuthServer("token-2", http.StatusForbidden, `Bearer error="insufficient_scope"`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var fetches atomic.Int32
+		var got string
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches), ForbiddenErrors: []string{"invalid_token"}},
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Equalf(t, fetches.Load(), 1, "\n\n"+
+			"UT Name:  The token isn't refreshed when the error isn't configured.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d (%v)\033[0m\n\n", fetches.Load(), err)
+	})
+
+	t.Run("When concurrent requests are rejected.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newAuthServer("token-2", http.StatusUnauthorized, `Bearer error="invalid_token"`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var fetches atomic.Int32
+		var wg sync.WaitGroup
+
+		auth := &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches)}
+		errs := make([]error, 10)
+
+		// ACT.
+		for i := range errs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				var got string
+
+				request := rapi.GETRequestMsg{
+					BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK, Auth: auth},
+				}
+
+				errs[i] = request.GETPlain(http.DefaultClient, &got)
+			}()
+		}
+
+		wg.Wait()
+
+		// ASSERT.
+		for _, err := range errs {
+			assert.Nilf(t, err, "\n\n"+
+				"UT Name:  NO 'error' is returned when the requests are replayed with a fresh token.\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", err)
+		}
+
+		assert.Equalf(t, fetches.Load(), 2, "\n\n"+
+			"UT Name:  Concurrent requests share a single refresh.\n"+
+			"\033[32mExpected: 2\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", fetches.Load())
+	})
+}
@@ -0,0 +1,222 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// The maximum number of bytes transferred at once by a limited reader or writer.
+const maxLimitedChunk = 32 * 1024
+
+// Limiter limits the bandwidth of transfers to a number of bytes per second.
+// A limiter can be shared by multiple transfers (e.g. all the requests of a client), which then share its bandwidth.
+// The limit can be adjusted at any time, even while transfers are in progress.
+type Limiter struct {
+	lock   sync.Mutex // Protect concurrent access to the limiter.
+	rate   int64      // The number of bytes per second. Zero (or less) is unlimited.
+	tokens float64    // The number of bytes which can be transferred without waiting. Negative when in debt.
+	last   time.Time  // The last time tokens were updated.
+}
+
+// NewLimiter returns a Limiter which limits the bandwidth to bytesPerSecond.
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	return &Limiter{rate: bytesPerSecond}
+}
+
+// SetLimit changes the bandwidth of l to bytesPerSecond. Zero (or less) is unlimited.
+func (l *Limiter) SetLimit(bytesPerSecond int64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill(time.Now())
+	l.rate = bytesPerSecond
+}
+
+// Limit returns the bandwidth of l, in bytes per second.
+func (l *Limiter) Limit() int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.rate
+}
+
+// Reader returns r, wrapped so that reading from it is limited by l.
+func (l *Limiter) Reader(r io.Reader) io.Reader {
+	return &limitedReader{ReadCloser: io.NopCloser(r), limiter: l}
+}
+
+// Writer returns w, wrapped so that writing to it is limited by l.
+func (l *Limiter) Writer(w io.Writer) io.Writer {
+	return &limitedWriter{w: w, limiter: l}
+}
+
+// Returns the maximum number of bytes to transfer at once, so that transfers are spread evenly over time.
+func (l *Limiter) chunk() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.rate <= 0 {
+		return maxLimitedChunk
+	}
+
+	return int(min(max(l.rate/10, 1), maxLimitedChunk))
+}
+
+// Takes n bytes from the bandwidth of l and returns how long to wait before transferring them.
+func (l *Limiter) take(n int) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.refill(time.Now())
+	l.tokens -= float64(n)
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / float64(l.rate) * float64(time.Second))
+}
+
+// Adds the bytes which became available since the last update to the tokens of l. The caller must hold l.lock.
+func (l *Limiter) refill(now time.Time) {
+	if !l.last.IsZero() && l.rate > 0 {
+		l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*float64(l.rate), float64(l.rate)/10)
+	}
+
+	if l.rate <= 0 {
+		l.tokens = 0
+	}
+
+	l.last = now
+}
+
+// BandwidthLimits limits the bandwidth of HTTP requests.
+// Set it on a BaseRequest to limit a single request, or use its Transport to limit all the requests of a client.
+type BandwidthLimits struct {
+	Upload   *Limiter // Limits sending the payloads of requests. When <nil>, it's unlimited.
+	Download *Limiter // Limits receiving the bodies of responses. When <nil>, it's unlimited.
+}
+
+// Transport returns base, wrapped so that the requests it sends are limited by limits.
+// When base is <nil>, http.DefaultTransport is used.
+func (limits *BandwidthLimits) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &limitedTransport{base: base, limits: limits}
+}
+
+// Returns body, wrapped so that reading from it is limited by limiter. When limiter is <nil>, body is returned.
+func limitBody(body io.ReadCloser, limiter *Limiter) io.ReadCloser {
+	if limiter == nil || body == nil || body == http.NoBody {
+		return body
+	}
+
+	return &limitedReader{ReadCloser: body, limiter: limiter}
+}
+
+// A transport which limits the bandwidth of the requests it sends.
+type limitedTransport struct {
+	base   http.RoundTripper // The wrapped transport.
+	limits *BandwidthLimits  // The limits.
+}
+
+// RoundTrip sends request using the wrapped transport and returns its response, limiting their bodies.
+func (t *limitedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil && t.limits.Upload != nil {
+		request = request.Clone(request.Context())
+		request.Body = limitBody(request.Body, t.limits.Upload)
+	}
+
+	response, err := t.base.RoundTrip(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = limitBody(response.Body, t.limits.Download)
+
+	return response, nil
+}
+
+// Returns the wrapped transport.
+func (t *limitedTransport) unwrap() http.RoundTripper {
+	return t.base
+}
+
+// A reader which limits the bandwidth of reading from the reader it wraps.
+type limitedReader struct {
+	io.ReadCloser          // The wrapped reader.
+	limiter       *Limiter // The limiter.
+}
+
+// Read reads from the wrapped reader into p, and waits until the bandwidth allows it.
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if chunk := r.limiter.chunk(); len(p) > chunk {
+		p = p[:chunk]
+	}
+
+	n, err := r.ReadCloser.Read(p)
+	time.Sleep(r.limiter.take(n))
+
+	return n, err
+}
+
+// A writer which limits the bandwidth of writing to the writer it wraps.
+type limitedWriter struct {
+	w       io.Writer // The wrapped writer.
+	limiter *Limiter  // The limiter.
+}
+
+// Write writes p to the wrapped writer in chunks, waiting until the bandwidth allows each chunk.
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	var written int
+
+	for len(p) > 0 {
+		chunk := p[:min(len(p), w.limiter.chunk())]
+		time.Sleep(w.limiter.take(len(chunk)))
+
+		n, err := w.w.Write(chunk)
+		written += n
+
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
@@ -0,0 +1,210 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/go-essentials/tstsrv"
+)
+
+// UT: Limit the bandwidth of HTTP requests.
+func TestBandwidthLimits(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the download of a request is limited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: strings.Repeat("x", 20_000)},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got string
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL(),
+				OkStatusCode: http.StatusOK,
+				Bandwidth:    &rapi.BandwidthLimits{Download: rapi.NewLimiter(100_000)},
+			},
+		}
+
+		// ACT.
+		start := time.Now()
+		err := request.GETPlain(http.DefaultClient, &got)
+		elapsed := time.Since(start)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the bandwidth is limited.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, elapsed >= 150*time.Millisecond, "\n\n"+
+			"UT Name:  The download takes at least as long as the limit allows.\n"+
+			"\033[32mExpected: >= 150ms\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", elapsed)
+	})
+
+	t.Run("When the upload of a request is limited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		payload := `"` + strings.Repeat("x", 20_000) + `"`
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Bandwidth:    &rapi.BandwidthLimits{Upload: rapi.NewLimiter(100_000)},
+			},
+			Payload: payload,
+		}
+
+		// ACT.
+		start := time.Now()
+		err := request.POST(http.DefaultClient, &got)
+		elapsed := time.Since(start)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the bandwidth is limited.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Body, payload, "\n\n"+
+			"UT Name:  The complete payload is sent.\n"+
+			"\033[32mExpected: %d bytes\033[0m\n"+
+			"\033[31mActual:   %d bytes\033[0m\n\n", len(payload), len(got.Body))
+
+		assert.Truef(t, elapsed >= 150*time.Millisecond, "\n\n"+
+			"UT Name:  The upload takes at least as long as the limit allows.\n"+
+			"\033[32mExpected: >= 150ms\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", elapsed)
+	})
+
+	t.Run("When the downloads of a client share a limit.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: strings.Repeat("x", 10_000)},
+					{StatusCode: http.StatusOK, Body: strings.Repeat("x", 10_000)},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var wg sync.WaitGroup
+
+		limits := &rapi.BandwidthLimits{Download: rapi.NewLimiter(100_000)}
+		client := &http.Client{Transport: limits.Transport(nil)}
+		errs := make([]error, 2)
+
+		// ACT.
+		start := time.Now()
+
+		for i := range errs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				var got string
+
+				request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+				errs[i] = request.GETPlain(client, &got)
+			}()
+		}
+
+		wg.Wait()
+
+		elapsed := time.Since(start)
+
+		// ASSERT.
+		for _, err := range errs {
+			assert.Nilf(t, err, "\n\n"+
+				"UT Name:  NO 'error' is returned when the bandwidth is limited.\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", err)
+		}
+
+		assert.Truef(t, elapsed >= 150*time.Millisecond, "\n\n"+
+			"UT Name:  The downloads share the bandwidth of the client.\n"+
+			"\033[32mExpected: >= 150ms\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", elapsed)
+	})
+
+	t.Run("When the limit is removed at runtime.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		var got bytes.Buffer
+
+		limiter := rapi.NewLimiter(1)
+		limiter.SetLimit(0)
+
+		// ACT.
+		start := time.Now()
+		limiter.Writer(&got).Write([]byte(strings.Repeat("x", 100_000)))
+		elapsed := time.Since(start)
+
+		// ASSERT.
+		assert.Truef(t, elapsed < time.Second && got.Len() == 100_000, "\n\n"+
+			"UT Name:  The transfer isn't limited once the limit is removed.\n"+
+			"\033[32mExpected: < 1s, 100000 bytes\033[0m\n"+
+			"\033[31mActual:   %v, %d bytes\033[0m\n\n", elapsed, got.Len())
+	})
+}
@@ -0,0 +1,282 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// Request describes an HTTP request, such as a *GETRequestMsg or a *POSTRequestMsg.
+// It's implemented by every type which embeds BaseRequest.
+type Request interface {
+	base() *BaseRequest
+}
+
+// Returns req.
+func (req *BaseRequest) base() *BaseRequest {
+	return req
+}
+
+// Batch describes the execution of many HTTP requests, with a limited number of concurrent requests.
+type Batch struct {
+	Context            context.Context              // The context of the batch, which cancels it. Defaults to context.Background().
+	Concurrency        int                          // The maximum number of concurrent requests. Defaults to 8.
+	ConcurrencyPerHost int                          // The maximum number of concurrent requests to the same host. Zero is unlimited.
+	FailFast           bool                         // Whether the batch is canceled when a request fails, instead of collecting all the results.
+	OnProgress         func(progress BatchProgress) // Invoked, one at a time, whenever a request is completed.
+}
+
+// BatchProgress describes the progress of a batch.
+type BatchProgress struct {
+	Started   int // The number of requests which are started.
+	Completed int // The number of requests which are completed, successfully or not.
+	Failed    int // The number of requests which failed.
+}
+
+// BatchResult describes the outcome of a request of a batch.
+type BatchResult[T any] struct {
+	Result T     // The result of the request.
+	Err    error // The error of the request, or <nil> when it succeeded.
+}
+
+// RunBatch uses client to make requests using do, such as (*GETRequestMsg).GET, as described by b. Requests are
+// started in order, unless their host already has the maximum number of concurrent requests, in which case they wait
+// without delaying the requests to other hosts. Their outcomes are returned in the order of requests. When the batch
+// is canceled, because of its context or because a request fails in fail-fast mode, the requests in progress are
+// aborted and the remaining ones aren't started, so only the outcomes of the started requests are returned.
+// It returns the error which canceled the batch in fail-fast mode, or the first error in the order of requests, or the
+// error of the context of b when it's canceled.
+func RunBatch[T any, R Request](client *http.Client, b *Batch, requests iter.Seq[R], do func(req R, client *http.Client, result any) error) ([]BatchResult[T], error) {
+	ctx, cancel := context.WithCancel(b.context())
+	defer cancel()
+
+	batchClient := *client
+	batchClient.Transport = &cancelTransport{base: client.Transport, ctx: ctx}
+
+	next, stop := iter.Pull(requests)
+	defer stop()
+
+	var (
+		lock      sync.Mutex
+		results   []BatchResult[T]
+		started   []bool
+		progress  BatchProgress
+		cause     error
+		queue     []batchJob[R]
+		active    int
+		exhausted bool
+	)
+
+	hostActive := make(map[string]int)
+	completed := make(chan string)
+
+	for {
+		for ctx.Err() == nil && active < b.concurrency() {
+			j := slices.IndexFunc(queue, func(job batchJob[R]) bool {
+				return b.ConcurrencyPerHost <= 0 || hostActive[job.host] < b.ConcurrencyPerHost
+			})
+
+			if j < 0 {
+				if exhausted {
+					break
+				}
+
+				req, ok := next()
+
+				if !ok {
+					exhausted = true
+
+					break
+				}
+
+				lock.Lock()
+				queue = append(queue, batchJob[R]{index: len(results), req: req, host: requestHost(req.base().Endpoint)})
+				results, started = append(results, BatchResult[T]{}), append(started, false)
+				lock.Unlock()
+
+				continue
+			}
+
+			job := queue[j]
+			queue = slices.Delete(queue, j, j+1)
+			active++
+			hostActive[job.host]++
+
+			lock.Lock()
+			started[job.index] = true
+			progress.Started++
+			lock.Unlock()
+
+			go func() {
+				defer func() { completed <- job.host }()
+
+				var result T
+
+				err := do(job.req, &batchClient, &result)
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				results[job.index] = BatchResult[T]{Result: result, Err: err}
+				progress.Completed++
+
+				if err != nil {
+					progress.Failed++
+
+					if b.FailFast && cause == nil {
+						cause = err
+						cancel()
+					}
+				}
+
+				if b.OnProgress != nil {
+					b.OnProgress(progress)
+				}
+			}()
+		}
+
+		if active == 0 {
+			break
+		}
+
+		host := <-completed
+		active--
+		hostActive[host]--
+	}
+
+	var outcomes []BatchResult[T]
+
+	for i, result := range results {
+		if started[i] {
+			outcomes = append(outcomes, result)
+		}
+	}
+
+	if cause != nil {
+		return outcomes, cause
+	}
+
+	for _, result := range outcomes {
+		if result.Err != nil {
+			return outcomes, result.Err
+		}
+	}
+
+	return outcomes, b.context().Err()
+}
+
+// A request of a batch, which waits until it can be started.
+type batchJob[R Request] struct {
+	index int    // The position of the request in the batch.
+	req   R      // The request.
+	host  string // The host of the request.
+}
+
+// Returns the context of b.
+func (b *Batch) context() context.Context {
+	if b.Context == nil {
+		return context.Background()
+	}
+
+	return b.Context
+}
+
+// Returns the maximum number of concurrent requests of b.
+func (b *Batch) concurrency() int {
+	if b.Concurrency <= 0 {
+		return 8
+	}
+
+	return b.Concurrency
+}
+
+// Returns the host of endpoint, of which the concurrent requests are limited together.
+func requestHost(endpoint string) string {
+	if parsed, err := url.Parse(endpoint); err == nil {
+		return parsed.Host
+	}
+
+	return ""
+}
+
+// A transport which aborts the requests it sends when its context is canceled.
+type cancelTransport struct {
+	base http.RoundTripper // The wrapped transport.
+	ctx  context.Context   // The context.
+}
+
+// RoundTrip sends request using the wrapped transport and returns its response. The request is aborted when the
+// context of t is canceled, until the body of the response is closed.
+func (t *cancelTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.base
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ctx, cancel := context.WithCancel(request.Context())
+	stop := context.AfterFunc(t.ctx, cancel)
+
+	response, err := base.RoundTrip(request.WithContext(ctx))
+
+	if err != nil {
+		stop()
+		cancel()
+
+		return nil, err
+	}
+
+	response.Body = &cancelBody{ReadCloser: response.Body, release: func() {
+		stop()
+		cancel()
+	}}
+
+	return response, nil
+}
+
+// Returns the wrapped transport.
+func (t *cancelTransport) unwrap() http.RoundTripper {
+	return t.base
+}
+
+// A body which releases the context of its request when it's closed.
+type cancelBody struct {
+	io.ReadCloser        // The wrapped body.
+	release       func() // Releases the context of the request.
+}
+
+// Close closes the wrapped body and releases the context of its request.
+func (b *cancelBody) Close() error {
+	defer b.release()
+
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,284 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// Returns a server which responds to "/<id>/<status>" with id and status, once target requests have been in progress
+// concurrently (or never when status is 0, until the request is aborted). The peak number of concurrent requests is
+// recorded in peak.
+func newConcurrencyServer(target int32, peak *atomic.Int32) *httptest.Server {
+	var current atomic.Int32
+	var once sync.Once
+
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/{id}/{status}", func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+
+		for old := peak.Load(); n > old && !peak.CompareAndSwap(old, n); old = peak.Load() {
+		}
+
+		if n >= target {
+			once.Do(func() { close(release) })
+		}
+
+		status, _ := strconv.Atoi(r.PathValue("status"))
+
+		if status == 0 {
+			<-r.Context().Done()
+
+			return
+		}
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(r.PathValue("id")))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// Returns GET requests to "/<i>/<status>" on the servers, alternately, where status is given by statuses.
+func batchRequests(servers []*httptest.Server, statuses ...int) iter.Seq[*rapi.GETRequestMsg] {
+	return func(yield func(*rapi.GETRequestMsg) bool) {
+		for i, status := range statuses {
+			request := &rapi.GETRequestMsg{
+				BaseRequest: rapi.BaseRequest{
+					Endpoint:     servers[i%len(servers)].URL + "/" + strconv.Itoa(i) + "/" + strconv.Itoa(status),
+					OkStatusCode: http.StatusOK,
+				},
+			}
+
+			if !yield(request) {
+				return
+			}
+		}
+	}
+}
+
+// UT: Make many HTTP requests with a limited number of concurrent requests.
+func TestRunBatch(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When all the results are collected.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var peakA, peakB atomic.Int32
+
+		srvFakeA, srvFakeB := newConcurrencyServer(1, &peakA), newConcurrencyServer(1, &peakB)
+
+		defer srvFakeA.Close()
+		defer srvFakeB.Close()
+
+		// ARRANGE.
+		var last rapi.BatchProgress
+
+		statuses := make([]int, 20)
+
+		for i := range statuses {
+			statuses[i] = http.StatusOK
+		}
+
+		statuses[5] = http.StatusInternalServerError
+
+		batch := rapi.Batch{
+			Concurrency:        3,
+			ConcurrencyPerHost: 1,
+			OnProgress:         func(progress rapi.BatchProgress) { last = progress },
+		}
+
+		// ACT.
+		got, err := rapi.RunBatch[int](http.DefaultClient, &batch, batchRequests([]*httptest.Server{srvFakeA, srvFakeB}, statuses...), (*rapi.GETRequestMsg).GET)
+
+		// ASSERT.
+		assert.Truef(t, rapi.IsServerError(err), "\n\n"+
+			"UT Name:  The 'error' of the failed request is returned.\n"+
+			"\033[32mExpected: status code 500\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, len(got), 20, "\n\n"+
+			"UT Name:  The outcomes of all the requests are collected.\n"+
+			"\033[32mExpected: 20\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", len(got))
+
+		for i, result := range got {
+			assert.Truef(t, (i == 5) == (result.Err != nil) && (i == 5 || result.Result == i), "\n\n"+
+				"UT Name:  The outcomes are returned in the order of the requests.\n"+
+				"\033[32mExpected: %d\033[0m\n"+
+				"\033[31mActual:   %d %v\033[0m\n\n", i, result.Result, result.Err)
+		}
+
+		assert.Truef(t, peakA.Load() == 1 && peakB.Load() == 1, "\n\n"+
+			"UT Name:  The number of concurrent requests to the same host is limited.\n"+
+			"\033[32mExpected: 1 1\033[0m\n"+
+			"\033[31mActual:   %d %d\033[0m\n\n", peakA.Load(), peakB.Load())
+
+		assert.Equalf(t, last, rapi.BatchProgress{Started: 20, Completed: 20, Failed: 1}, "\n\n"+
+			"UT Name:  The progress of the batch is reported.\n"+
+			"\033[32mExpected: {Started:20 Completed:20 Failed:1}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", last)
+	})
+
+	t.Run("When the number of concurrent requests is limited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var peak atomic.Int32
+
+		srvFake := newConcurrencyServer(4, &peak)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		statuses := make([]int, 20)
+
+		for i := range statuses {
+			statuses[i] = http.StatusOK
+		}
+
+		batch := rapi.Batch{Concurrency: 4}
+
+		// ACT.
+		got, err := rapi.RunBatch[int](http.DefaultClient, &batch, batchRequests([]*httptest.Server{srvFake}, statuses...), (*rapi.GETRequestMsg).GET)
+
+		// ASSERT.
+		assert.Truef(t, err == nil && len(got) == 20, "\n\n"+
+			"UT Name:  NO 'error' is returned when all the requests succeed.\n"+
+			"\033[32mExpected: 20 <nil>\033[0m\n"+
+			"\033[31mActual:   %d %v\033[0m\n\n", len(got), err)
+
+		assert.Equalf(t, peak.Load(), int32(4), "\n\n"+
+			"UT Name:  The number of concurrent requests is limited.\n"+
+			"\033[32mExpected: 4\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", peak.Load())
+	})
+
+	t.Run("When the batch fails fast.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var peak atomic.Int32
+
+		srvFake := newConcurrencyServer(1, &peak)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var statusErr *rapi.StatusError
+
+		batch := rapi.Batch{Concurrency: 2, FailFast: true}
+		requests := batchRequests([]*httptest.Server{srvFake}, 0, http.StatusBadRequest, 0, 0, 0)
+
+		// ACT.
+		got, err := rapi.RunBatch[int](http.DefaultClient, &batch, requests, (*rapi.GETRequestMsg).GET)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest, "\n\n"+
+			"UT Name:  The 'error' of the request which failed first is returned.\n"+
+			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, len(got) == 2 && got[0].Err != nil, "\n\n"+
+			"UT Name:  The requests in progress are aborted and the remaining ones aren't started.\n"+
+			"\033[32mExpected: 2 outcomes, of which the first is aborted\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", got)
+	})
+
+	t.Run("When the requests to a busy host come first.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var once sync.Once
+		var waited atomic.Int32
+
+		received := make(chan struct{})
+
+		srvFakeA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-received:
+			case <-time.After(time.Second):
+				waited.Add(1)
+			}
+
+			w.Write([]byte("1"))
+		}))
+
+		srvFakeB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			once.Do(func() { close(received) })
+			w.Write([]byte("2"))
+		}))
+
+		defer srvFakeA.Close()
+		defer srvFakeB.Close()
+
+		// ARRANGE.
+		var requests []*rapi.GETRequestMsg
+
+		for _, endpoint := range []string{srvFakeA.URL, srvFakeA.URL, srvFakeA.URL, srvFakeB.URL} {
+			requests = append(requests, &rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: endpoint, OkStatusCode: http.StatusOK}})
+		}
+
+		batch := rapi.Batch{Concurrency: 2, ConcurrencyPerHost: 1}
+
+		// ACT.
+		got, err := rapi.RunBatch[int](http.DefaultClient, &batch, slices.Values(requests), (*rapi.GETRequestMsg).GET)
+
+		// ASSERT.
+		assert.Truef(t, err == nil && len(got) == 4 && got[0].Result == 1 && got[3].Result == 2, "\n\n"+
+			"UT Name:  The outcomes of all the requests are returned in the order of the requests.\n"+
+			"\033[32mExpected: 4 outcomes <nil>\033[0m\n"+
+			"\033[31mActual:   %+v %v\033[0m\n\n", got, err)
+
+		assert.Equalf(t, waited.Load(), int32(0), "\n\n"+
+			"UT Name:  The request to another host is started while the busy host has queued requests.\n"+
+			"\033[32mExpected: 0 requests waited for it in vain\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", waited.Load())
+	})
+}
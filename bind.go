@@ -0,0 +1,198 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The type of a time.Time.
+var timeType = reflect.TypeFor[time.Time]()
+
+// Updates the fields of result which are tagged with `rapi:"header:<name>"` or `rapi:"status"` using the headers and
+// the status code of response, and the ones tagged with `rapi:"idempotency-key"` or `rapi:"replayed"` using the
+// idempotency key sent according to idempotency and whether response reports that it's replayed.
+// Results which aren't pointers to structs are left untouched.
+// It returns an error if a header can't be converted to the type of its field.
+func bindResponse(response *http.Response, result any, idempotency *Idempotency) error {
+	value := reflect.ValueOf(result)
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct || !value.CanSet() {
+		return nil
+	}
+
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		tag, found := field.Tag.Lookup("rapi")
+
+		if !found || !field.IsExported() {
+			continue
+		}
+
+		kind, name, _ := strings.Cut(tag, ":")
+
+		switch kind {
+		case "status":
+			if err := parseValue(strconv.Itoa(response.StatusCode), value.Field(i)); err != nil {
+				return fmt.Errorf("failed to bind status code: %w", err)
+			}
+
+		case "header":
+			if err := parseValues(response.Header.Values(name), value.Field(i)); err != nil {
+				return fmt.Errorf("failed to bind header %q: %w", name, err)
+			}
+
+		case "idempotency-key":
+			if response.Request != nil {
+				if err := parseValues(response.Request.Header.Values(idempotency.header()), value.Field(i)); err != nil {
+					return fmt.Errorf("failed to bind idempotency key: %w", err)
+				}
+			}
+
+		case "replayed":
+			replayed, _ := strconv.ParseBool(response.Header.Get(idempotency.replayedHeader()))
+
+			if err := parseValue(strconv.FormatBool(replayed), value.Field(i)); err != nil {
+				return fmt.Errorf("failed to bind replayed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Updates value using texts. Slices receive all the texts, other types only the first one.
+// Values are left untouched when there are no texts.
+// It returns an error if a text can't be converted to the type of value.
+func parseValues(texts []string, value reflect.Value) error {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(value.Type(), len(texts), len(texts))
+
+		for i, text := range texts {
+			if err := parseValue(text, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		value.Set(slice)
+
+		return nil
+	}
+
+	return parseValue(texts[0], value)
+}
+
+// Updates value using the textual representation text. Times are parsed as HTTP dates.
+// It returns an error if text can't be converted to the type of value.
+func parseValue(text string, value reflect.Value) error {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return parseValue(text, value.Elem())
+	}
+
+	if value.Type() == timeType {
+		parsed, err := http.ParseTime(text)
+
+		if err != nil {
+			return err
+		}
+
+		value.Set(reflect.ValueOf(parsed))
+
+		return nil
+	}
+
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(text))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(parsed)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(text), 10, value.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(parsed)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(strings.TrimSpace(text), 10, value.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		value.SetUint(parsed)
+
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(text), value.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(parsed)
+
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
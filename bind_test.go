@@ -0,0 +1,150 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// UT: Bind the headers and the status code of an HTTP response into the result.
+func TestBindResponse(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the result has tagged fields.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("X-Total-Count", "42")
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			w.Header().Add("Link", "</page/2>; rel=next")
+			w.Header().Add("Link", "</page/9>; rel=last")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"0"}`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		type Response struct {
+			Id           string    `json:"id"`
+			Status       int       `json:"-" rapi:"status"`
+			ETag         string    `json:"-" rapi:"header:ETag"`
+			TotalCount   *int      `json:"-" rapi:"header:X-Total-Count"`
+			LastModified time.Time `json:"-" rapi:"header:Last-Modified"`
+			Links        []string  `json:"-" rapi:"header:Link"`
+			Missing      string    `json:"-" rapi:"header:X-Missing"`
+		}
+
+		var got Response
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusCreated},
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the headers can be converted.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Id, "0", "\n\n"+
+			"UT Name:  The body is decoded.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Id)
+
+		assert.Equalf(t, got.Status, http.StatusCreated, "\n\n"+
+			"UT Name:  The status code is bound.\n"+
+			"\033[32mExpected: 201\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", got.Status)
+
+		assert.Equalf(t, got.ETag, `"v1"`, "\n\n"+
+			"UT Name:  The string header is bound.\n"+
+			"\033[32mExpected: \"v1\"\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.ETag)
+
+		assert.Equalf(t, *got.TotalCount, 42, "\n\n"+
+			"UT Name:  The integer header is bound.\n"+
+			"\033[32mExpected: 42\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", *got.TotalCount)
+
+		assert.Truef(t, got.LastModified.Equal(time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)), "\n\n"+
+			"UT Name:  The HTTP-date header is bound.\n"+
+			"\033[32mExpected: 2015-10-21 07:28:00 +0000 UTC\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", got.LastModified)
+
+		assert.EqualSf(t, got.Links, []string{"</page/2>; rel=next", "</page/9>; rel=last"}, "\n\n"+
+			"UT Name:  The repeated header is bound.\n"+
+			"\033[32mExpected: [</page/2>; rel=next </page/9>; rel=last]\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", got.Links)
+
+		assert.Equalf(t, got.Missing, "", "\n\n"+
+			"UT Name:  A missing header is left untouched.\n"+
+			"\033[32mExpected: \033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Missing)
+	})
+
+	t.Run("When a header can't be converted.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Total-Count", "many")
+			w.Write([]byte(`{}`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got struct {
+			TotalCount int `json:"-" rapi:"header:X-Total-Count"`
+		}
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when a header can't be converted.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
@@ -0,0 +1,149 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// The headers which carry credentials, which always distinguish requests.
+var credentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// Coalescer coalesces identical concurrent HTTP GET (and HEAD) requests, so they share a single request to the
+// server. Requests are identical when they are made with the same client and have the same method, URL, values for
+// Headers and for the headers which carry credentials (Authorization, Cookie and Proxy-Authorization), authentication,
+// accepted encodings and limits on the size of the body. Only the response is shared: every request checks its status
+// code and decodes its body on its own, using its own handlers and results.
+// The shared request isn't canceled along with the context of the request which started it, but only once the contexts
+// of all the requests which share it are canceled. When it fails, the requests which share it fail as well. Its
+// progress is only reported to (and its bandwidth only limited by) the request which started it.
+// A Coalescer must not be copied after first use.
+type Coalescer struct {
+	Headers []string // The headers which distinguish requests, besides their method and URL.
+
+	lock  sync.Mutex                // Protects calls.
+	calls map[string]*coalescedCall // The requests in progress, by key.
+}
+
+// A request in progress, which is shared by identical requests.
+type coalescedCall struct {
+	done     chan struct{}      // Closed when the request is completed.
+	cancel   context.CancelFunc // Cancels the request.
+	waiters  int                // The number of requests which wait for the request, protected by the lock of the Coalescer.
+	data     []byte             // The body of the response.
+	response *http.Response     // The response, of which the body is closed and the status code isn't checked.
+	err      error              // The error of the request.
+}
+
+// Returns the key which identifies the HTTP request with method described by req, which is made using client.
+func (c *Coalescer) key(client *http.Client, method string, req *BaseRequest) string {
+	var key strings.Builder
+
+	fmt.Fprintf(&key, "%s %s %p %p", method, appendQuery(req.Endpoint, req.Query), client, req.Auth)
+
+	if req.Compression != nil {
+		fmt.Fprintf(&key, "\nCompression: %q %d", req.Compression.Accept, req.Compression.MaxDecompressedSize)
+	}
+
+	if req.ResponseOptions != nil {
+		fmt.Fprintf(&key, "\nMax body size: %d", req.ResponseOptions.MaxBodySize)
+	}
+
+	for _, header := range slices.Concat(credentialHeaders, c.Headers) {
+		header = http.CanonicalHeaderKey(header)
+
+		for name, value := range req.HttpHeaders {
+			if http.CanonicalHeaderKey(name) == header {
+				fmt.Fprintf(&key, "\n%s: %s", header, value)
+			}
+		}
+	}
+
+	return key.String()
+}
+
+// Makes the request identified by key using send, unless an identical request is in progress, in which case its
+// outcome is shared. The request is made with a context which has the values of ctx, and which is only canceled once
+// every request which waits for it stops waiting, which happens when its ctx is canceled.
+// It returns the error of the shared request, or the error of ctx when it's canceled first.
+func (c *Coalescer) do(ctx context.Context, key string, send func(ctx context.Context) ([]byte, *http.Response, error)) ([]byte, *http.Response, error) {
+	c.lock.Lock()
+
+	call, found := c.calls[key]
+
+	if !found {
+		sendCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &coalescedCall{done: make(chan struct{}), cancel: cancel}
+
+		if c.calls == nil {
+			c.calls = make(map[string]*coalescedCall)
+		}
+
+		c.calls[key] = call
+
+		go func() {
+			call.data, call.response, call.err = send(sendCtx)
+
+			c.lock.Lock()
+
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+
+			c.lock.Unlock()
+
+			close(call.done)
+			cancel()
+		}()
+	}
+
+	call.waiters++
+	c.lock.Unlock()
+
+	select {
+	case <-call.done:
+		return call.data, call.response, call.err
+
+	case <-ctx.Done():
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if call.waiters--; call.waiters == 0 {
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+
+			call.cancel()
+		}
+
+		return nil, nil, ctx.Err()
+	}
+}
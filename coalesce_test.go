@@ -0,0 +1,398 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// Returns a server which counts the requests in count and responds to them with status once release is closed. The
+// requests which are aborted before are counted in aborted.
+func newBlockingServer(count, aborted *atomic.Int32, release <-chan struct{}, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			aborted.Add(1)
+
+			return
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(`{"tags":["go","http"],"tenant":"` + r.Header.Get("X-Tenant") + `","msg":"bad"}`))
+	}))
+}
+
+// A context which counts the calls to Done in waiting, which are made by a coalesced request when it starts waiting for
+// the identical request in progress.
+type waitingContext struct {
+	context.Context
+
+	waiting *atomic.Int32
+}
+
+// Done returns the Done channel of the underlying context, and counts the call.
+func (ctx waitingContext) Done() <-chan struct{} {
+	ctx.waiting.Add(1)
+
+	return ctx.Context.Done()
+}
+
+// UT: Coalesce identical concurrent HTTP GET requests.
+func TestCoalescer(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Tags   []string `json:"tags"`
+		Tenant string   `json:"tenant"`
+	}
+
+	type Failure struct {
+		Msg string `json:"msg"`
+	}
+
+	// A GET request, and its outcome.
+	type call struct {
+		client  *http.Client
+		tenant  string
+		token   string
+		result  Response
+		failure Failure
+		err     error
+	}
+
+	// Makes the GET request described by c on srv with ctx, using coalescer.
+	get := func(srv *httptest.Server, coalescer *rapi.Coalescer, ctx context.Context, c *call) {
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Context:      ctx,
+				Endpoint:     srv.URL,
+				HttpHeaders:  map[string]string{"X-Tenant": c.tenant},
+				OkStatusCode: http.StatusOK,
+				ErrorResult:  &c.failure,
+				Coalesce:     coalescer,
+			},
+		}
+
+		if c.token != "" {
+			request.HttpHeaders["Authorization"] = "Bearer " + c.token
+		}
+
+		c.err = request.GET(c.client, &c.result)
+	}
+
+	// Makes the leading calls concurrently, using coalescer, and waits until srv received as many requests as
+	// expected. Then makes the following calls, and waits until they wait for an identical request, before release is
+	// closed and all the calls complete.
+	run := func(srv *httptest.Server, count *atomic.Int32, expected int32, release chan struct{}, coalescer *rapi.Coalescer, leaders, followers []*call) {
+		var wg sync.WaitGroup
+		var waiting atomic.Int32
+
+		start := func(ctx context.Context, c *call) {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				get(srv, coalescer, ctx, c)
+			}()
+		}
+
+		for _, c := range leaders {
+			start(context.Background(), c)
+		}
+
+		waitFor(func() bool { return count.Load() == expected })
+
+		for _, c := range followers {
+			start(waitingContext{Context: context.Background(), waiting: &waiting}, c)
+		}
+
+		waitFor(func() bool { return waiting.Load() == int32(len(followers)) })
+		close(release)
+		wg.Wait()
+	}
+
+	t.Run("When identical requests are made concurrently.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusOK)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		coalescer := &rapi.Coalescer{}
+		calls := []*call{{client: http.DefaultClient, tenant: "a"}, {client: http.DefaultClient, tenant: "b"}, {client: http.DefaultClient, tenant: "c"}}
+
+		// ACT.
+		run(srvFake, &count, 1, release, coalescer, calls[:1], calls[1:])
+
+		// ASSERT.
+		assert.Equalf(t, count.Load(), int32(1), "\n\n"+
+			"UT Name:  Identical concurrent requests share a single request.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+
+		calls[0].result.Tags[0] = "modified"
+
+		for i, c := range calls {
+			assert.Nilf(t, c.err, "\n\n"+
+				"UT Name:  NO 'error' is returned when requests are coalesced.\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", c.err)
+
+			assert.Truef(t, i == 0 || c.result.Tags[0] == "go", "\n\n"+
+				"UT Name:  Every request decodes its own copy of the result.\n"+
+				"\033[32mExpected: go\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", c.result.Tags[0])
+		}
+	})
+
+	t.Run("When identical requests fail.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusBadRequest)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		coalescer := &rapi.Coalescer{}
+		calls := []*call{{client: http.DefaultClient, tenant: "a"}, {client: http.DefaultClient, tenant: "b"}}
+
+		// ACT.
+		run(srvFake, &count, 1, release, coalescer, calls[:1], calls[1:])
+
+		// ASSERT.
+		assert.Equalf(t, count.Load(), int32(1), "\n\n"+
+			"UT Name:  Identical concurrent requests share a single request, even when it fails.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+
+		for _, c := range calls {
+			var statusErr *rapi.StatusError
+
+			assert.Truef(t, errors.As(c.err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest && statusErr.Result == &c.failure, "\n\n"+
+				"UT Name:  Every request returns a 'rapi.StatusError' with its own error result.\n"+
+				"\033[32mExpected: status code 400\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", c.err)
+
+			assert.Equalf(t, c.failure, Failure{Msg: "bad"}, "\n\n"+
+				"UT Name:  Every request decodes the body of the failed request into its own error result.\n"+
+				"\033[32mExpected: {Msg:bad}\033[0m\n"+
+				"\033[31mActual:   %+v\033[0m\n\n", c.failure)
+		}
+	})
+
+	t.Run("When requests differ in a selected header.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusOK)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		coalescer := &rapi.Coalescer{Headers: []string{"x-tenant"}}
+		calls := []*call{{client: http.DefaultClient, tenant: "a"}, {client: http.DefaultClient, tenant: "b"}, {client: http.DefaultClient, tenant: "a"}, {client: http.DefaultClient, tenant: "b"}}
+
+		// ACT.
+		run(srvFake, &count, 2, release, coalescer, calls[:2], calls[2:])
+
+		// ASSERT.
+		assert.Equalf(t, count.Load(), int32(2), "\n\n"+
+			"UT Name:  Requests which differ in a selected header aren't coalesced.\n"+
+			"\033[32mExpected: 2\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+
+		got := []string{calls[0].result.Tenant, calls[1].result.Tenant, calls[2].result.Tenant, calls[3].result.Tenant}
+
+		assert.Truef(t, got[0] == "a" && got[1] == "b" && got[2] == "a" && got[3] == "b", "\n\n"+
+			"UT Name:  Every request receives the result of its identical requests.\n"+
+			"\033[32mExpected: [a b a b]\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", got)
+	})
+
+	t.Run("When requests are made with different clients.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusOK)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		coalescer := &rapi.Coalescer{}
+		calls := []*call{{client: http.DefaultClient, tenant: "a"}, {client: &http.Client{}, tenant: "a"}}
+
+		// ACT.
+		run(srvFake, &count, 2, release, coalescer, calls, nil)
+
+		// ASSERT.
+		assert.Equalf(t, count.Load(), int32(2), "\n\n"+
+			"UT Name:  Requests which are made with different clients aren't coalesced.\n"+
+			"\033[32mExpected: 2\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+	})
+
+	t.Run("When requests differ in their credentials.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusOK)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		coalescer := &rapi.Coalescer{}
+		calls := []*call{{client: http.DefaultClient, tenant: "a", token: "alice"}, {client: http.DefaultClient, tenant: "b", token: "bob"}}
+
+		// ACT.
+		run(srvFake, &count, 2, release, coalescer, calls, nil)
+
+		// ASSERT.
+		assert.Truef(t, count.Load() == 2 && calls[0].result.Tenant == "a" && calls[1].result.Tenant == "b", "\n\n"+
+			"UT Name:  Requests with different credentials in their headers aren't coalesced, even when the headers aren't selected.\n"+
+			"\033[32mExpected: 2 a b\033[0m\n"+
+			"\033[31mActual:   %d %s %s\033[0m\n\n", count.Load(), calls[0].result.Tenant, calls[1].result.Tenant)
+	})
+
+	t.Run("When the request which started the shared request is canceled.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusOK)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var waiting atomic.Int32
+
+		coalescer := &rapi.Coalescer{}
+		leader, follower := &call{client: http.DefaultClient, tenant: "a"}, &call{client: http.DefaultClient, tenant: "b"}
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderDone, followerDone := make(chan struct{}), make(chan struct{})
+
+		// ACT.
+		go func() {
+			defer close(leaderDone)
+
+			get(srvFake, coalescer, ctx, leader)
+		}()
+
+		waitFor(func() bool { return count.Load() == 1 })
+
+		go func() {
+			defer close(followerDone)
+
+			get(srvFake, coalescer, waitingContext{Context: context.Background(), waiting: &waiting}, follower)
+		}()
+
+		waitFor(func() bool { return waiting.Load() == 1 })
+		cancel()
+		<-leaderDone
+		close(release)
+		<-followerDone
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(leader.err, context.Canceled), "\n\n"+
+			"UT Name:  The 'error' of its context is returned to the request which is canceled.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", context.Canceled, leader.err)
+
+		assert.Truef(t, follower.err == nil && follower.result.Tags[0] == "go" && aborted.Load() == 0, "\n\n"+
+			"UT Name:  The shared request isn't aborted while other requests wait for it.\n"+
+			"\033[32mExpected: <nil> [go http] 0\033[0m\n"+
+			"\033[31mActual:   %v %v %d\033[0m\n\n", follower.err, follower.result.Tags, aborted.Load())
+	})
+
+	t.Run("When all the requests which share a request are canceled.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count, aborted atomic.Int32
+
+		release := make(chan struct{})
+		srvFake := newBlockingServer(&count, &aborted, release, http.StatusOK)
+
+		defer srvFake.Close()
+		defer close(release)
+
+		// ARRANGE.
+		coalescer := &rapi.Coalescer{}
+		leader := &call{client: http.DefaultClient, tenant: "a"}
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderDone := make(chan struct{})
+
+		// ACT.
+		go func() {
+			defer close(leaderDone)
+
+			get(srvFake, coalescer, ctx, leader)
+		}()
+
+		waitFor(func() bool { return count.Load() == 1 })
+		cancel()
+		<-leaderDone
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(leader.err, context.Canceled) && waitFor(func() bool { return aborted.Load() == 1 }), "\n\n"+
+			"UT Name:  The shared request is aborted once all the requests which share it are canceled.\n"+
+			"\033[32mExpected: %v 1\033[0m\n"+
+			"\033[31mActual:   %v %d\033[0m\n\n", context.Canceled, leader.err, aborted.Load())
+	})
+}
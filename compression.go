@@ -0,0 +1,272 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrDecompressionLimit is returned when a decompressed body exceeds Compression.MaxDecompressedSize.
+var ErrDecompressionLimit = errors.New("decompressed body exceeds the limit")
+
+// Encoding describes a content encoding (see RFC 9110, section 8.4.1).
+type Encoding struct {
+	Name    string                                    // The name of the encoding, such as "gzip".
+	Encoder func(w io.Writer) (io.WriteCloser, error) // Returns a writer which compresses into w.
+	Decoder func(r io.Reader) (io.ReadCloser, error)  // Returns a reader which decompresses r.
+}
+
+// The registered encodings, by name, and their names in order of preference.
+var (
+	encodingsLock sync.RWMutex
+	encodings     = map[string]Encoding{}
+	encodingNames []string
+)
+
+// Register the built-in encodings.
+func init() {
+	RegisterEncoding(Encoding{
+		Name: "deflate",
+		Encoder: func(w io.Writer) (io.WriteCloser, error) {
+			return zlib.NewWriterLevel(w, flate.DefaultCompression)
+		},
+		Decoder: func(r io.Reader) (io.ReadCloser, error) {
+			return zlib.NewReader(r)
+		},
+	})
+
+	RegisterEncoding(Encoding{
+		Name: "gzip",
+		Encoder: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+		Decoder: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	})
+
+	RegisterEncoding(Encoding{
+		Name: "zstd",
+		Encoder: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w)
+		},
+		Decoder: func(r io.Reader) (io.ReadCloser, error) {
+			decoder, err := zstd.NewReader(r)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return decoder.IOReadCloser(), nil
+		},
+	})
+}
+
+// RegisterEncoding registers encoding, so it can compress payloads and decompress responses. The "gzip", "deflate"
+// and "zstd" encodings are registered by default; other encodings, such as "br", must be registered using a
+// third-party implementation.
+// Encodings registered later are preferred, and registering a name again replaces the encoding.
+func RegisterEncoding(encoding Encoding) {
+	encodingsLock.Lock()
+	defer encodingsLock.Unlock()
+
+	name := strings.ToLower(encoding.Name)
+
+	if _, found := encodings[name]; !found {
+		encodingNames = append([]string{name}, encodingNames...)
+	}
+
+	encodings[name] = encoding
+}
+
+// Returns the registered encoding named name.
+func lookupEncoding(name string) (Encoding, bool) {
+	encodingsLock.RLock()
+	defer encodingsLock.RUnlock()
+
+	encoding, found := encodings[strings.ToLower(name)]
+
+	return encoding, found
+}
+
+// Compression describes the compression of the payload of an HTTP request and the body of its response.
+// Responses are decompressed using the registered encodings, even when Accept-Encoding is set in the headers.
+type Compression struct {
+	Encoding            string   // The encoding used to compress the payload. When empty, it isn't compressed.
+	Accept              []string // The encodings accepted for the response. When empty, all registered ones are.
+	MaxDecompressedSize int64    // The maximum size of a decompressed body. Zero is unlimited.
+}
+
+// Returns the value of the Accept-Encoding header for c.
+func (c *Compression) acceptEncoding() string {
+	if len(c.Accept) > 0 {
+		return strings.Join(c.Accept, ", ")
+	}
+
+	encodingsLock.RLock()
+	defer encodingsLock.RUnlock()
+
+	return strings.Join(encodingNames, ", ")
+}
+
+// Returns body, compressed using the encoding named name.
+// It returns an error if the encoding isn't registered, in which case body is closed.
+func compressBody(body io.Reader, name string) (io.Reader, error) {
+	encoding, found := lookupEncoding(name)
+
+	if !found || encoding.Encoder == nil {
+		closeReader(body)
+
+		return nil, fmt.Errorf("unsupported content encoding %q", name)
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		encoder, err := encoding.Encoder(writer)
+
+		if err == nil {
+			_, err = io.Copy(encoder, body)
+
+			if closeErr := encoder.Close(); err == nil {
+				err = closeErr
+			}
+		}
+
+		closeReader(body)
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+// Decompresses the body of response using the encodings in its Content-Encoding header. The body is left untouched
+// when an encoding isn't registered. A decompressed body larger than maxSize fails with ErrDecompressionLimit.
+// It returns an error if the body can't be decompressed.
+func decompressBody(response *http.Response, maxSize int64) error {
+	header := response.Header.Get("Content-Encoding")
+
+	if header == "" {
+		return nil
+	}
+
+	var names []string
+
+	for name := range strings.SplitSeq(header, ",") {
+		if name = strings.TrimSpace(name); name != "" && !strings.EqualFold(name, "identity") {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if _, found := lookupEncoding(name); !found {
+			return nil
+		}
+	}
+
+	body := &decodedBody{Reader: response.Body, closers: []io.Closer{response.Body}}
+
+	for i := len(names) - 1; i >= 0; i-- {
+		encoding, _ := lookupEncoding(names[i])
+		decoder, err := encoding.Decoder(body.Reader)
+
+		if err != nil {
+			body.Close()
+
+			return fmt.Errorf("failed to decompress response body: %w", err)
+		}
+
+		body.Reader = decoder
+		body.closers = append(body.closers, decoder)
+	}
+
+	if maxSize > 0 {
+		body.Reader = &limitedBody{r: body.Reader, remaining: maxSize, err: ErrDecompressionLimit}
+	}
+
+	response.Body = body
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+
+	return nil
+}
+
+// A decompressed body, which closes its decoders and the original body.
+type decodedBody struct {
+	io.Reader             // The decompressed body.
+	closers   []io.Closer // The decoders and the original body.
+}
+
+// Close closes the decoders and the original body.
+func (body *decodedBody) Close() error {
+	var errs []error
+
+	for i := len(body.closers) - 1; i >= 0; i-- {
+		errs = append(errs, body.closers[i].Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// A reader which fails with err once more than remaining bytes are read.
+type limitedBody struct {
+	r         io.Reader // The wrapped reader.
+	remaining int64     // The number of bytes which can still be read.
+	err       error     // The error returned when the limit is exceeded.
+}
+
+// Read reads from the wrapped reader into p.
+// It returns the limit error if the wrapped reader has more data than allowed.
+func (body *limitedBody) Read(p []byte) (int, error) {
+	if body.remaining < 0 {
+		return 0, body.err
+	}
+
+	if int64(len(p)) > body.remaining+1 {
+		p = p[:body.remaining+1]
+	}
+
+	n, err := body.r.Read(p)
+	body.remaining -= int64(n)
+
+	if body.remaining < 0 {
+		return n + int(body.remaining), body.err
+	}
+
+	return n, err
+}
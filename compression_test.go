@@ -0,0 +1,302 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Register a custom encoding, as it would be done for "br".
+func init() {
+	rapi.RegisterEncoding(rapi.Encoding{
+		Name: "x-base64",
+		Encoder: func(w io.Writer) (io.WriteCloser, error) {
+			return base64.NewEncoder(base64.StdEncoding, w), nil
+		},
+		Decoder: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(base64.NewDecoder(base64.StdEncoding, r)), nil
+		},
+	})
+}
+
+// Returns body compressed using encoding.
+func compress(encoding, body string) []byte {
+	var buffer bytes.Buffer
+	var writer io.WriteCloser
+
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buffer)
+
+	case "deflate":
+		writer = zlib.NewWriter(&buffer)
+
+	case "zstd":
+		writer, _ = zstd.NewWriter(&buffer)
+
+	default:
+		writer = base64.NewEncoder(base64.StdEncoding, &buffer)
+	}
+
+	writer.Write([]byte(body))
+	writer.Close()
+
+	return buffer.Bytes()
+}
+
+// Returns a server which responds with body, compressed using encoding, and which echoes the decompressed payload
+// and the Accept-Encoding header of the request in the X-Payload and X-Accept-Encoding headers.
+func newCompressionServer(encoding, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload []byte
+
+		switch r.Header.Get("Content-Encoding") {
+		case "gzip":
+			reader, _ := gzip.NewReader(r.Body)
+			payload, _ = io.ReadAll(reader)
+
+		case "zstd":
+			reader, _ := zstd.NewReader(r.Body)
+			payload, _ = io.ReadAll(reader)
+			reader.Close()
+
+		default:
+			payload, _ = io.ReadAll(r.Body)
+		}
+
+		w.Header().Set("X-Payload", string(payload))
+		w.Header().Set("X-Accept-Encoding", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", encoding)
+		w.Write(compress(encoding, body))
+	}))
+}
+
+// UT: Compress the payloads of HTTP requests and decompress their responses.
+func TestCompression(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Id             string `json:"id"`
+		Payload        string `json:"-" rapi:"header:X-Payload"`
+		AcceptEncoding string `json:"-" rapi:"header:X-Accept-Encoding"`
+	}
+
+	t.Run("When the payload is compressed.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCompressionServer("deflate", `{"id":"0"}`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Compression:  &rapi.Compression{Encoding: "gzip"},
+			},
+			Payload: `{"name":"rapi"}`,
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the payload is compressed.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Payload, `{"name":"rapi"}`, "\n\n"+
+			"UT Name:  The payload is compressed using the configured encoding.\n"+
+			"\033[32mExpected: {\"name\":\"rapi\"}\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Payload)
+
+		assert.Equalf(t, got.Id, "0", "\n\n"+
+			"UT Name:  The deflate encoded response is decompressed.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Id)
+
+		assert.Truef(t, strings.Contains(got.AcceptEncoding, "x-base64"), "\n\n"+
+			"UT Name:  The registered encodings are accepted.\n"+
+			"\033[32mExpected: x-base64, ...\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.AcceptEncoding)
+	})
+
+	t.Run("When the payload and the response are zstd encoded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCompressionServer("zstd", `{"id":"0"}`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Compression:  &rapi.Compression{Encoding: "zstd"},
+			},
+			Payload: `{"name":"rapi"}`,
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the payload and the response are zstd encoded.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, got.Payload == `{"name":"rapi"}` && got.Id == "0", "\n\n"+
+			"UT Name:  The payload is zstd encoded and the zstd encoded response is decompressed.\n"+
+			"\033[32mExpected: {\"name\":\"rapi\"} 0\033[0m\n"+
+			"\033[31mActual:   %s %s\033[0m\n\n", got.Payload, got.Id)
+
+		assert.Truef(t, strings.Contains(got.AcceptEncoding, "zstd"), "\n\n"+
+			"UT Name:  The zstd encoding is accepted by default.\n"+
+			"\033[32mExpected: zstd, ...\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.AcceptEncoding)
+	})
+
+	t.Run("When the Accept-Encoding header is set explicitly.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCompressionServer("gzip", `{"id":"0"}`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				HttpHeaders:  map[string]string{"Accept-Encoding": "gzip"},
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the response is gzip encoded.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Id, "0", "\n\n"+
+			"UT Name:  The gzip encoded response is decompressed.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Id)
+	})
+
+	t.Run("When the response uses a registered encoding.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCompressionServer("x-base64", `{"id":"0"}`)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Compression:  &rapi.Compression{Accept: []string{"x-base64"}},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the response uses a registered encoding.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Id, "0", "\n\n"+
+			"UT Name:  The response is decompressed using the registered encoding.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Id)
+	})
+
+	t.Run("When the decompressed response exceeds the limit.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCompressionServer("gzip", strings.Repeat("0", 1_000_000))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got string
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Compression:  &rapi.Compression{MaxDecompressedSize: 1024},
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, rapi.ErrDecompressionLimit), "\n\n"+
+			"UT Name:  'rapi.ErrDecompressionLimit' is returned when the decompressed response exceeds the limit.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrDecompressionLimit, err)
+	})
+}
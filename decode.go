@@ -0,0 +1,238 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrBodyTooLarge is returned when the body of a response exceeds ResponseOptions.MaxBodySize.
+var ErrBodyTooLarge = errors.New("response body exceeds the limit")
+
+// ErrUnexpectedContentType is returned (wrapped in a DecodeError) when the Content-Type of a response isn't JSON.
+var ErrUnexpectedContentType = errors.New("unexpected content type")
+
+// The maximum length of the snippet of the body in a DecodeError.
+const maxSnippetLength = 256
+
+// DecodeError is returned when the body of a response can't be decoded.
+type DecodeError struct {
+	Method      string // The HTTP method of the request.
+	URL         string // The URL of the request, with its password redacted.
+	StatusCode  int    // The HTTP status code of the response.
+	ContentType string // The Content-Type of the response.
+	Snippet     string // The start of the body, truncated to 256 bytes.
+	Err         error  // The reason why the body can't be decoded.
+}
+
+// Error returns the description of err.
+func (err *DecodeError) Error() string {
+	return fmt.Sprintf("%v (status code %d, content type %q, body %q)", err.Err, err.StatusCode, err.ContentType, err.Snippet)
+}
+
+// Unwrap returns the reason why the body can't be decoded.
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+// Returns a DecodeError for the body data of response, which can't be decoded because of err.
+func newDecodeError(response *http.Response, data []byte, err error) *DecodeError {
+	snippet := data
+
+	if len(snippet) > maxSnippetLength {
+		snippet = snippet[:maxSnippetLength]
+	}
+
+	decodeErr := &DecodeError{
+		StatusCode:  response.StatusCode,
+		ContentType: response.Header.Get("Content-Type"),
+		Snippet:     strings.ToValidUTF8(string(snippet), ""),
+		Err:         err,
+	}
+
+	if len(data) > maxSnippetLength {
+		decodeErr.Snippet += "..."
+	}
+
+	if response.Request != nil {
+		decodeErr.Method, decodeErr.URL = response.Request.Method, response.Request.URL.Redacted()
+	}
+
+	return decodeErr
+}
+
+// Reports whether the Content-Type header of response can be decoded as JSON. Besides JSON media types, an absent
+// header and text/plain are accepted, since many servers (including Go's, which sniffs it) send JSON as plain text.
+func isJSONContentType(response *http.Response) bool {
+	header := response.Header.Get("Content-Type")
+
+	if header == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header)
+
+	return err == nil && (mediaType == "application/json" || mediaType == "text/plain" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// ResponseOptions describes how the body of an HTTP response is read and decoded.
+// Set it on a BaseRequest to configure a single request, or use its Transport to configure all the requests of a
+// client. The options of a request replace those of its client.
+// Like json.Unmarshal, data following the JSON value is rejected unless AllowTrailingData is set.
+type ResponseOptions struct {
+	MaxBodySize           int64 // The maximum size of the body, in bytes. Zero is unlimited.
+	DisallowUnknownFields bool  // Whether JSON objects with fields which don't exist in the result are rejected.
+	UseNumber             bool  // Whether JSON numbers are decoded into an 'any' as a json.Number.
+	AllowTrailingData     bool  // Whether data following the JSON value is ignored instead of rejected.
+	ZeroEmptyResult       bool  // Whether an empty body resets the result to its zero value instead of leaving it.
+	IgnoreContentType     bool  // Whether a body is decoded as JSON, whatever its Content-Type.
+}
+
+// Transport returns base, wrapped so that the requests it sends use opts, unless they have their own options.
+// When base is <nil>, http.DefaultTransport is used.
+func (opts *ResponseOptions) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &optionsTransport{base: base, opts: opts}
+}
+
+// Returns the options for reading the response of req when it's sent using client.
+func (req *BaseRequest) options(client *http.Client) *ResponseOptions {
+	if req.ResponseOptions != nil {
+		return req.ResponseOptions
+	}
+
+	transport := client.Transport
+
+	for transport != nil {
+		switch t := transport.(type) {
+		case *optionsTransport:
+			return t.opts
+
+		case interface{ unwrap() http.RoundTripper }:
+			transport = t.unwrap()
+
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Decodes the JSON in data into result, as defined by opts.
+// It returns an error if data can't be decoded.
+func decodeJSON(data []byte, result any, opts *ResponseOptions) error {
+	if opts == nil {
+		opts = &ResponseOptions{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	if opts.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if opts.UseNumber {
+		decoder.UseNumber()
+	}
+
+	if err := decoder.Decode(&result); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	if _, err := decoder.Token(); err != io.EOF && !opts.AllowTrailingData {
+		return errors.New("failed to unmarshal JSON: trailing data after the JSON value")
+	}
+
+	return nil
+}
+
+// Limits the body of response to maxSize bytes. Reading a larger body fails with ErrBodyTooLarge.
+// It returns ErrBodyTooLarge if the Content-Length of the response already exceeds maxSize.
+func limitResponseBody(response *http.Response, maxSize int64) error {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	if response.ContentLength > maxSize {
+		response.Body.Close()
+
+		return fmt.Errorf("%w (%d > %d bytes)", ErrBodyTooLarge, response.ContentLength, maxSize)
+	}
+
+	response.Body = &limitedReadCloser{
+		Reader: &limitedBody{r: response.Body, remaining: maxSize, err: ErrBodyTooLarge},
+		Closer: response.Body,
+	}
+
+	return nil
+}
+
+// A limited body, which closes the body it wraps.
+type limitedReadCloser struct {
+	io.Reader // The limited body.
+	io.Closer // The wrapped body.
+}
+
+// A transport which applies options to the responses of the requests it sends.
+type optionsTransport struct {
+	base http.RoundTripper // The wrapped transport.
+	opts *ResponseOptions  // The options.
+}
+
+// RoundTrip sends request using the wrapped transport and returns its response, with its body limited.
+func (t *optionsTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.base.RoundTrip(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := limitResponseBody(response, t.opts.MaxBodySize); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Returns the wrapped transport.
+func (t *optionsTransport) unwrap() http.RoundTripper {
+	return t.base
+}
@@ -0,0 +1,355 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/go-essentials/tstsrv"
+)
+
+// UT: Read and decode the body of HTTP responses.
+func TestResponseOptions(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Id string `json:"id"`
+	}
+
+	t.Run("When the body exceeds the maximum size of the request.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":"` + strings.Repeat("0", 1024) + `"}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:        srvFake.URL(),
+				OkStatusCode:    http.StatusOK,
+				ResponseOptions: &rapi.ResponseOptions{MaxBodySize: 512},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, rapi.ErrBodyTooLarge), "\n\n"+
+			"UT Name:  'rapi.ErrBodyTooLarge' is returned when the body exceeds the maximum size.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrBodyTooLarge, err)
+	})
+
+	t.Run("When the body exceeds the maximum size of the client.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":"` + strings.Repeat("0", 1024) + `"}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		opts := &rapi.ResponseOptions{MaxBodySize: 512}
+		client := &http.Client{Transport: opts.Transport(nil)}
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(client, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, rapi.ErrBodyTooLarge), "\n\n"+
+			"UT Name:  'rapi.ErrBodyTooLarge' is returned when the body exceeds the maximum size.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrBodyTooLarge, err)
+	})
+
+	t.Run("When the client disallows unknown fields.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":"0","name":"rapi"}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		opts := &rapi.ResponseOptions{DisallowUnknownFields: true}
+		limits := &rapi.BandwidthLimits{}
+		client := &http.Client{Transport: limits.Transport(opts.Transport(nil))}
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(client, &got)
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when the JSON contains an unknown field.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When numbers are decoded as json.Number.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":12345678901234567890}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got map[string]any
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:        srvFake.URL(),
+				OkStatusCode:    http.StatusOK,
+				ResponseOptions: &rapi.ResponseOptions{UseNumber: true},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the JSON is valid.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got["id"], any(json.Number("12345678901234567890")), "\n\n"+
+			"UT Name:  The number is decoded as a json.Number.\n"+
+			"\033[32mExpected: 12345678901234567890\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", got["id"])
+	})
+
+	t.Run("When the JSON value is followed by trailing data.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":"0"} {"id":"1"}`},
+					{StatusCode: http.StatusOK, Body: `{"id":"0"} {"id":"1"}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var strict, lenient Response
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		strictErr := request.GET(http.DefaultClient, &strict)
+		request.ResponseOptions = &rapi.ResponseOptions{AllowTrailingData: true}
+		lenientErr := request.GET(http.DefaultClient, &lenient)
+
+		// ASSERT.
+		assert.NotNilf(t, strictErr, "\n\n"+
+			"UT Name:  An 'error' is returned when the JSON value is followed by trailing data.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", strictErr)
+
+		assert.Nilf(t, lenientErr, "\n\n"+
+			"UT Name:  NO 'error' is returned when trailing data is allowed.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", lenientErr)
+
+		assert.Equalf(t, lenient.Id, "0", "\n\n"+
+			"UT Name:  The first JSON value is decoded when trailing data is allowed.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", lenient.Id)
+	})
+}
+
+// UT: Report the responses of which the body can't be decoded.
+func TestDecodeError(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Id string `json:"id"`
+	}
+
+	t.Run("When the body is an HTML page.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: "<!DOCTYPE html><html><body>" + strings.Repeat("Proxy error. ", 50) + "</body></html>"},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+		var decodeErr *rapi.DecodeError
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &decodeErr), "\n\n"+
+			"UT Name:  A 'rapi.DecodeError' is returned when the body is an HTML page.\n"+
+			"\033[32mExpected: *rapi.DecodeError\033[0m\n"+
+			"\033[31mActual:   %T\033[0m\n\n", err)
+
+		assert.Truef(t, errors.Is(err, rapi.ErrUnexpectedContentType), "\n\n"+
+			"UT Name:  'rapi.ErrUnexpectedContentType' is returned when the body is an HTML page.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrUnexpectedContentType, err)
+
+		assert.Truef(t, strings.HasPrefix(decodeErr.ContentType, "text/html"), "\n\n"+
+			"UT Name:  The 'rapi.DecodeError' contains the content type of the response.\n"+
+			"\033[32mExpected: text/html\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", decodeErr.ContentType)
+
+		assert.Equalf(t, decodeErr.StatusCode, http.StatusOK, "\n\n"+
+			"UT Name:  The 'rapi.DecodeError' contains the status code of the response.\n"+
+			"\033[32mExpected: %d\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", http.StatusOK, decodeErr.StatusCode)
+
+		assert.Equalf(t, decodeErr.URL, srvFake.URL(), "\n\n"+
+			"UT Name:  The 'rapi.DecodeError' contains the URL of the request.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", srvFake.URL(), decodeErr.URL)
+
+		assert.Truef(t, strings.HasPrefix(decodeErr.Snippet, "<!DOCTYPE html>") && len(decodeErr.Snippet) == 259, "\n\n"+
+			"UT Name:  The 'rapi.DecodeError' contains a truncated snippet of the body.\n"+
+			"\033[32mExpected: The first 256 bytes of the body, followed by '...'\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", decodeErr.Snippet)
+	})
+
+	t.Run("When the body is invalid JSON.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+		var decodeErr *rapi.DecodeError
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &decodeErr) && decodeErr.Snippet == `{"id":`, "\n\n"+
+			"UT Name:  A 'rapi.DecodeError' with the body is returned when the body is invalid JSON.\n"+
+			"\033[32mExpected: {\"id\":\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the content type is ignored.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`{"id":"0"}`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:        srvFake.URL,
+				OkStatusCode:    http.StatusOK,
+				ResponseOptions: &rapi.ResponseOptions{IgnoreContentType: true},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the content type is ignored.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
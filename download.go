@@ -0,0 +1,435 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DownloadOptions describes how the body of an HTTP response is downloaded.
+//
+// The downloaded content is verified against the first available checksum of: SHA256, the "sha-256" Repr-Digest
+// header, the "sha-256" Content-Digest header (when the content wasn't resumed) and, when VerifyETag is set, the ETag.
+type DownloadOptions struct {
+	SHA256     string // The expected hex encoded SHA-256 hash of the content.
+	VerifyETag bool   // Whether a strong ETag which is a hex encoded MD5 or SHA-256 hash (such as S3's) is verified.
+	MaxResumes int    // The number of times an interrupted download is resumed.
+}
+
+// ChecksumError is returned when the checksum of downloaded content doesn't match the expected checksum.
+type ChecksumError struct {
+	Algorithm string // The algorithm of the checksum, such as "sha-256".
+	Expected  string // The expected checksum.
+	Actual    string // The checksum of the downloaded content.
+}
+
+// Error returns the description of err.
+func (err *ChecksumError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch: expected %s, got %s", err.Algorithm, err.Expected, err.Actual)
+}
+
+// Download uses client to make an HTTP GET request described by req and writes the body of the response to path.
+// The body is written to "<path>.part" first, which is renamed to path once the download is complete and verified.
+// An interrupted download is resumed, using a Range request, up to opts.MaxResumes times. When it can't be completed,
+// the partial file is kept, so a later call resumes it as long as the resource didn't change.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *GETRequestMsg) Download(client *http.Client, path string, opts DownloadOptions) error {
+	return req.wrapError(http.MethodGet, req.downloadFile(client, path, opts))
+}
+
+// Uses client to make an HTTP GET request described by req and writes the body of the response to path.
+// It returns an error if the file can't be written, or if the download fails or can't be verified.
+func (req *GETRequestMsg) downloadFile(client *http.Client, path string, opts DownloadOptions) error {
+	partPath, validatorPath := path+".part", path+".part.validator"
+	file, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0o644)
+
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	d := newDownload(opts, file)
+	d.restart = func() error {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		return file.Truncate(0)
+	}
+
+	d.saveValidator = func(validator string) error {
+		return os.WriteFile(validatorPath, []byte(validator), 0o644)
+	}
+
+	if validator, err := os.ReadFile(validatorPath); err == nil && len(validator) > 0 {
+		d.validator = string(validator)
+		d.offset, err = io.Copy(d.hashes, file)
+
+		if err != nil {
+			file.Close()
+
+			return fmt.Errorf("failed to read partial file: %w", err)
+		}
+	} else if err := d.restart(); err != nil {
+		file.Close()
+
+		return fmt.Errorf("failed to truncate partial file: %w", err)
+	}
+
+	if err := d.run(client, &req.BaseRequest); err != nil {
+		file.Close()
+
+		if d.offset == 0 {
+			os.Remove(partPath)
+			os.Remove(validatorPath)
+		}
+
+		return err
+	}
+
+	if err := d.verify(); err != nil {
+		file.Close()
+		os.Remove(partPath)
+		os.Remove(validatorPath)
+
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	os.Remove(validatorPath)
+
+	if err := os.Rename(partPath, path); err != nil {
+		return fmt.Errorf("failed to rename file: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadTo uses client to make an HTTP GET request described by req and streams the body of the response to w.
+// An interrupted download is resumed, using a Range request, up to opts.MaxResumes times. The checksum is verified
+// once the download is complete, which is after the content is written to w.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *GETRequestMsg) DownloadTo(client *http.Client, w io.Writer, opts DownloadOptions) error {
+	d := newDownload(opts, w)
+
+	if err := d.run(client, &req.BaseRequest); err != nil {
+		return req.wrapError(http.MethodGet, err)
+	}
+
+	return req.wrapError(http.MethodGet, d.verify())
+}
+
+// The state of a download.
+type download struct {
+	opts          DownloadOptions              // The options of the download.
+	w             io.Writer                    // The writer the content is written to.
+	sha256        hash.Hash                    // The SHA-256 hash of the content written so far.
+	md5           hash.Hash                    // The MD5 hash of the content written so far.
+	hashes        io.Writer                    // The writer which updates all hashes.
+	offset        int64                        // The number of bytes written so far.
+	resumed       bool                         // Whether the content was received in multiple responses.
+	validator     string                       // The ETag or Last-Modified date which identifies the downloaded resource.
+	etag          string                       // The strong ETag of the downloaded resource.
+	reprDigest    string                       // The base64 encoded SHA-256 of the resource, from the Repr-Digest header.
+	contentDigest string                       // The base64 encoded SHA-256 of the content, from the Content-Digest header.
+	restart       func() error                 // Discards the content written so far. When <nil>, it can't be discarded.
+	saveValidator func(validator string) error // Persists the validator, so a later download can resume.
+}
+
+// Returns a download which writes the content to w.
+func newDownload(opts DownloadOptions, w io.Writer) *download {
+	d := &download{opts: opts, w: w, sha256: sha256.New(), md5: md5.New()}
+	d.hashes = io.MultiWriter(d.sha256, d.md5)
+
+	return d
+}
+
+// Uses client to download the body of the HTTP GET request described by req.
+// It returns an error if the download can't be completed.
+func (d *download) run(client *http.Client, req *BaseRequest) error {
+	for attempt := 0; ; attempt++ {
+		resumable, err := d.attempt(client, req)
+
+		if err == nil || !resumable || d.validator == "" || attempt >= d.opts.MaxResumes {
+			return err
+		}
+	}
+}
+
+// Uses client to download the remaining body of the HTTP GET request described by req.
+// It returns an error, and whether the download can be resumed, if the download can't be completed.
+func (d *download) attempt(client *http.Client, req *BaseRequest) (bool, error) {
+	base := *req
+	base.HttpHeaders = maps.Clone(req.HttpHeaders)
+
+	if base.HttpHeaders == nil {
+		base.HttpHeaders = make(map[string]string)
+	}
+
+	if d.offset > 0 {
+		base.HttpHeaders["Range"] = fmt.Sprintf("bytes=%d-", d.offset)
+
+		if d.validator != "" {
+			base.HttpHeaders["If-Range"] = d.validator
+		}
+	}
+
+	response, err := base.receive(client, http.MethodGet, nil, func(statusCode int) bool {
+		return d.offset > 0 && (statusCode == http.StatusPartialContent || statusCode == http.StatusRequestedRangeNotSatisfiable)
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		if _, _, total, ok := parseContentRange(response.Header.Get("Content-Range")); ok && total == d.offset {
+			return false, nil
+		}
+
+		return false, &StatusError{StatusCode: response.StatusCode}
+
+	case http.StatusPartialContent:
+		if start, _, _, ok := parseContentRange(response.Header.Get("Content-Range")); !ok || start != d.offset {
+			return false, fmt.Errorf("unexpected content range %q", response.Header.Get("Content-Range"))
+		}
+
+		d.resumed = true
+
+	default:
+		if err := d.reset(); err != nil {
+			return false, err
+		}
+	}
+
+	if err := d.inspect(response); err != nil {
+		return false, err
+	}
+
+	writer := &errWriter{w: d.w}
+	written, err := io.Copy(io.MultiWriter(writer, d.hashes), response.Body)
+	d.offset += written
+
+	if writer.err != nil {
+		return false, fmt.Errorf("failed to write response body: %w", writer.err)
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return false, nil
+}
+
+// Records the validator and the digests of response.
+// It returns an error if the validator can't be saved.
+func (d *download) inspect(response *http.Response) error {
+	if etag := response.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		d.etag = etag
+	}
+
+	validator := d.etag
+
+	if validator == "" {
+		validator = response.Header.Get("Last-Modified")
+	}
+
+	if digest := parseDigest(response.Header.Get("Repr-Digest")); digest != "" {
+		d.reprDigest = digest
+	}
+
+	if response.StatusCode != http.StatusPartialContent {
+		d.contentDigest = parseDigest(response.Header.Get("Content-Digest"))
+	}
+
+	if validator != d.validator {
+		d.validator = validator
+
+		if d.saveValidator != nil && validator != "" {
+			if err := d.saveValidator(validator); err != nil {
+				return fmt.Errorf("failed to save validator: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Discards the content written so far.
+// It returns an error if the content can't be discarded.
+func (d *download) reset() error {
+	if d.offset > 0 {
+		if d.restart == nil {
+			return errors.New("download can't be restarted: the resource changed")
+		}
+
+		if err := d.restart(); err != nil {
+			return fmt.Errorf("failed to restart download: %w", err)
+		}
+	}
+
+	d.offset, d.resumed = 0, false
+	d.sha256.Reset()
+	d.md5.Reset()
+
+	return nil
+}
+
+// Verifies the downloaded content against the expected checksum.
+// It returns an error if the checksum doesn't match or <nil> when there's no expected checksum.
+func (d *download) verify() error {
+	sum := d.sha256.Sum(nil)
+
+	switch etag := strings.Trim(d.etag, `"`); {
+	case d.opts.SHA256 != "":
+		return compareChecksum("sha-256", strings.ToLower(d.opts.SHA256), hex.EncodeToString(sum))
+
+	case d.reprDigest != "":
+		return compareChecksum("sha-256", d.reprDigest, base64.StdEncoding.EncodeToString(sum))
+
+	case d.contentDigest != "" && !d.resumed:
+		return compareChecksum("sha-256", d.contentDigest, base64.StdEncoding.EncodeToString(sum))
+
+	case d.opts.VerifyETag && len(etag) == 2*sha256.Size && isHexString(etag):
+		return compareChecksum("sha-256", strings.ToLower(etag), hex.EncodeToString(sum))
+
+	case d.opts.VerifyETag && len(etag) == 2*md5.Size && isHexString(etag):
+		return compareChecksum("md5", strings.ToLower(etag), hex.EncodeToString(d.md5.Sum(nil)))
+	}
+
+	return nil
+}
+
+// Returns a ChecksumError if actual doesn't match expected.
+func compareChecksum(algorithm, expected, actual string) error {
+	if expected != actual {
+		return &ChecksumError{Algorithm: algorithm, Expected: expected, Actual: actual}
+	}
+
+	return nil
+}
+
+// Reports whether s only consists of hexadecimal digits.
+func isHexString(s string) bool {
+	for i := range len(s) {
+		if !isHex(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the base64 encoded "sha-256" digest in the Repr-Digest or Content-Digest header (see RFC 9530).
+func parseDigest(header string) string {
+	for member := range strings.SplitSeq(header, ",") {
+		if algorithm, value, found := strings.Cut(strings.TrimSpace(member), "="); found && algorithm == "sha-256" {
+			return strings.Trim(value, ":")
+		}
+	}
+
+	return ""
+}
+
+// Returns the first and last position, and the total length, of the Content-Range header.
+// The positions are -1 for an unsatisfied range, and the total length is -1 when it's unknown.
+func parseContentRange(header string) (int64, int64, int64, bool) {
+	unit, spec, found := strings.Cut(header, " ")
+
+	if !found || unit != "bytes" {
+		return 0, 0, 0, false
+	}
+
+	positions, length, found := strings.Cut(spec, "/")
+
+	if !found {
+		return 0, 0, 0, false
+	}
+
+	total := int64(-1)
+
+	if length != "*" {
+		var err error
+
+		if total, err = strconv.ParseInt(length, 10, 64); err != nil {
+			return 0, 0, 0, false
+		}
+	}
+
+	if positions == "*" {
+		return -1, -1, total, true
+	}
+
+	firstText, lastText, found := strings.Cut(positions, "-")
+	first, firstErr := strconv.ParseInt(firstText, 10, 64)
+	last, lastErr := strconv.ParseInt(lastText, 10, 64)
+
+	if !found || firstErr != nil || lastErr != nil {
+		return 0, 0, 0, false
+	}
+
+	return first, last, total, true
+}
+
+// A writer which records the first error returned by the writer it wraps.
+type errWriter struct {
+	w   io.Writer // The wrapped writer.
+	err error     // The first error returned by w.
+}
+
+// Write writes p to the wrapped writer, and records the error it returns.
+func (w *errWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+
+	return n, err
+}
@@ -0,0 +1,229 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// The content served by a download server.
+var downloadContent = strings.Repeat("HELLO, WORLD! ", 1024)
+
+// Returns a server which serves downloadContent, with support for Range requests. The connection of the first
+// interrupted requests is dropped halfway through the body. The number of Range requests is counted in ranges.
+func newDownloadServer(interrupted int32, ranges *atomic.Int32) *httptest.Server {
+	var count atomic.Int32
+
+	sum := sha256.Sum256([]byte(downloadContent))
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Repr-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":")
+
+		if r.Header.Get("Range") != "" {
+			ranges.Add(1)
+		}
+
+		if count.Add(1) <= interrupted {
+			w.Header().Set("Content-Length", "99999")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(downloadContent[:len(downloadContent)/2]))
+			w.(http.Flusher).Flush()
+
+			panic(http.ErrAbortHandler)
+		}
+
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(downloadContent))
+	}))
+}
+
+// UT: Download the body of an HTTP response.
+func TestDownload(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	sum := sha256.Sum256([]byte(downloadContent))
+	checksum := hex.EncodeToString(sum[:])
+
+	t.Run("When the download is interrupted.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var ranges atomic.Int32
+
+		srvFake := newDownloadServer(1, &ranges)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		path := filepath.Join(t.TempDir(), "download.txt")
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.Download(http.DefaultClient, path, rapi.DownloadOptions{SHA256: checksum, MaxResumes: 1})
+		got, _ := os.ReadFile(path)
+		_, partErr := os.Stat(path + ".part")
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the download is resumed.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, string(got), downloadContent, "\n\n"+
+			"UT Name:  The complete content is written to the file.\n"+
+			"\033[32mExpected: %d bytes\033[0m\n"+
+			"\033[31mActual:   %d bytes\033[0m\n\n", len(downloadContent), len(got))
+
+		assert.Equalf(t, ranges.Load(), 1, "\n\n"+
+			"UT Name:  The download is resumed using a Range request.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", ranges.Load())
+
+		assert.Truef(t, os.IsNotExist(partErr), "\n\n"+
+			"UT Name:  The partial file is renamed.\n"+
+			"\033[32mExpected: file doesn't exist\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", partErr)
+	})
+
+	t.Run("When the download is resumed by a later call.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var ranges atomic.Int32
+
+		srvFake := newDownloadServer(1, &ranges)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		path := filepath.Join(t.TempDir(), "download.txt")
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+		firstErr := request.Download(http.DefaultClient, path, rapi.DownloadOptions{})
+
+		// ACT.
+		err := request.Download(http.DefaultClient, path, rapi.DownloadOptions{})
+		got, _ := os.ReadFile(path)
+
+		// ASSERT.
+		assert.NotNilf(t, firstErr, "\n\n"+
+			"UT Name:  An 'error' is returned when the download is interrupted and can't be resumed.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", firstErr)
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the download is resumed by a later call.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, string(got), downloadContent, "\n\n"+
+			"UT Name:  The complete content is written to the file.\n"+
+			"\033[32mExpected: %d bytes\033[0m\n"+
+			"\033[31mActual:   %d bytes\033[0m\n\n", len(downloadContent), len(got))
+
+		assert.Equalf(t, ranges.Load(), 1, "\n\n"+
+			"UT Name:  The download is resumed using a Range request.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", ranges.Load())
+	})
+
+	t.Run("When the checksum doesn't match.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var ranges atomic.Int32
+
+		srvFake := newDownloadServer(0, &ranges)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var checksumErr *rapi.ChecksumError
+
+		path := filepath.Join(t.TempDir(), "download.txt")
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.Download(http.DefaultClient, path, rapi.DownloadOptions{SHA256: strings.Repeat("0", 64)})
+		_, statErr := os.Stat(path)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &checksumErr), "\n\n"+
+			"UT Name:  A '*rapi.ChecksumError' is returned when the checksum doesn't match.\n"+
+			"\033[32mExpected: *rapi.ChecksumError\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, os.IsNotExist(statErr), "\n\n"+
+			"UT Name:  The file isn't created when the checksum doesn't match.\n"+
+			"\033[32mExpected: file doesn't exist\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", statErr)
+	})
+
+	t.Run("When the content is streamed to a writer.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var ranges atomic.Int32
+
+		srvFake := newDownloadServer(1, &ranges)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got bytes.Buffer
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.DownloadTo(http.DefaultClient, &got, rapi.DownloadOptions{MaxResumes: 1})
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the content matches the Repr-Digest header.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.String(), downloadContent, "\n\n"+
+			"UT Name:  The complete content is written to the writer.\n"+
+			"\033[32mExpected: %d bytes\033[0m\n"+
+			"\033[31mActual:   %d bytes\033[0m\n\n", len(downloadContent), got.Len())
+	})
+}
@@ -0,0 +1,177 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// The HTTP status codes of the responses after which a request can be retried.
+var retryableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooEarly,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RequestError is returned when an HTTP request fails. It wraps the error describing the failure, which can be
+// classified using IsTimeout, IsNetwork, IsClientError, IsServerError, IsDecodeError and IsRetryable.
+type RequestError struct {
+	Method string // The HTTP method of the request.
+	URL    string // The URL of the request, with its password redacted.
+	Err    error  // The error describing the failure, such as a *url.Error, a *StatusError or a *DecodeError.
+}
+
+// Error returns the description of err.
+func (err *RequestError) Error() string {
+	cause := err.Err
+
+	// A *url.Error already describes the method and the URL of the request.
+	if urlErr, ok := cause.(*url.Error); ok {
+		cause = urlErr.Err
+	}
+
+	return fmt.Sprintf("%s %s: %v", err.Method, err.URL, cause)
+}
+
+// Unwrap returns the error describing the failure.
+func (err *RequestError) Unwrap() error {
+	return err.Err
+}
+
+// Returns err wrapped in a RequestError for the HTTP request with method described by req, or <nil> when err is <nil>.
+func (req *BaseRequest) wrapError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	endpoint := appendQuery(req.Endpoint, req.Query)
+
+	if parsed, parseErr := url.Parse(endpoint); parseErr == nil {
+		endpoint = parsed.Redacted()
+	}
+
+	return &RequestError{Method: method, URL: endpoint, Err: err}
+}
+
+// IsTimeout reports whether err is caused by a timeout, such as an expired deadline or http.Client.Timeout.
+func IsTimeout(err error) bool {
+	var timeoutErr interface{ Timeout() bool }
+
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeoutErr) && timeoutErr.Timeout())
+}
+
+// IsNetwork reports whether err is caused by the network, such as a DNS failure, a refused connection or a connection
+// which is closed before the response is received.
+func IsNetwork(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	var urlErr *url.Error
+
+	// A truncated body is reported as io.ErrUnexpectedEOF by the JSON decoder as well.
+	if IsDecodeError(err) {
+		return false
+	}
+
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	return errors.As(err, &urlErr) && errors.Is(urlErr.Err, io.EOF)
+}
+
+// IsClientError reports whether err is caused by a response with a 4xx status code.
+func IsClientError(err error) bool {
+	statusCode, found := errorStatusCode(err)
+
+	return found && statusCode >= 400 && statusCode < 500
+}
+
+// IsServerError reports whether err is caused by a response with a 5xx status code.
+func IsServerError(err error) bool {
+	statusCode, found := errorStatusCode(err)
+
+	return found && statusCode >= 500 && statusCode < 600
+}
+
+// IsDecodeError reports whether err is caused by a body which can't be decoded.
+func IsDecodeError(err error) bool {
+	var decodeErr *DecodeError
+
+	return errors.As(err, &decodeErr)
+}
+
+// IsRetryable reports whether the request which caused err can be retried, because err is caused by a timeout, the
+// network or a response with a status code such as 429 (Too Many Requests) or 503 (Service Unavailable).
+// Requests which are canceled, or of which the host doesn't exist, can't be retried.
+func IsRetryable(err error) bool {
+	var dnsErr *net.DNSError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return false
+
+	case IsTimeout(err):
+		return true
+
+	case IsNetwork(err):
+		return !errors.As(err, &dnsErr) || !dnsErr.IsNotFound
+	}
+
+	statusCode, found := errorStatusCode(err)
+
+	return found && slices.Contains(retryableStatusCodes, statusCode)
+}
+
+// Returns the HTTP status code of the response which caused err.
+// It reports whether err is caused by a response with a status code which isn't accepted.
+func errorStatusCode(err error) (int, bool) {
+	var statusErr *StatusError
+	var problem *Problem
+
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode, true
+
+	case errors.As(err, &problem):
+		return problem.Status, true
+
+	case errors.Is(err, ErrNotImplemented):
+		return http.StatusNotImplemented, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,277 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/go-essentials/tstsrv"
+)
+
+// UT: Classify the errors of failed HTTP requests.
+func TestRequestError(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Id string `json:"id"`
+	}
+
+	type Classification struct {
+		Timeout, Network, ClientError, ServerError, DecodeError, Retryable bool
+	}
+
+	classify := func(err error) Classification {
+		return Classification{
+			Timeout:     rapi.IsTimeout(err),
+			Network:     rapi.IsNetwork(err),
+			ClientError: rapi.IsClientError(err),
+			ServerError: rapi.IsServerError(err),
+			DecodeError: rapi.IsDecodeError(err),
+			Retryable:   rapi.IsRetryable(err),
+		}
+	}
+
+	t.Run("When the response has a 4xx status code.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusNotFound},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Equalf(t, classify(err), Classification{ClientError: true}, "\n\n"+
+			"UT Name:  A client error is returned when the response has a 4xx status code.\n"+
+			"\033[32mExpected: %+v\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", Classification{ClientError: true}, classify(err))
+	})
+
+	t.Run("When the response has a 5xx status code.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusServiceUnavailable},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var requestErr *rapi.RequestError
+
+		request := rapi.POSTRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Equalf(t, classify(err), Classification{ServerError: true, Retryable: true}, "\n\n"+
+			"UT Name:  A retryable server error is returned when the response has status code 503.\n"+
+			"\033[32mExpected: %+v\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", Classification{ServerError: true, Retryable: true}, classify(err))
+
+		assert.Truef(t, errors.As(err, &requestErr) && requestErr.Method == http.MethodPost && requestErr.URL == srvFake.URL(), "\n\n"+
+			"UT Name:  A 'rapi.RequestError' with the method and the URL of the request is returned.\n"+
+			"\033[32mExpected: POST %s\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", srvFake.URL(), err)
+	})
+
+	t.Run("When the body can't be decoded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Equalf(t, classify(err), Classification{DecodeError: true}, "\n\n"+
+			"UT Name:  A decode error is returned when the body can't be decoded.\n"+
+			"\033[32mExpected: %+v\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", Classification{DecodeError: true}, classify(err))
+	})
+
+	t.Run("When the body of a 5xx response can't be decoded into the error result.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusBadGateway, Body: "<!DOCTYPE html><html><body>Bad gateway.</body></html>"},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var statusErr *rapi.StatusError
+		var failure struct {
+			Message string `json:"message"`
+		}
+
+		want := Classification{ServerError: true, DecodeError: true, Retryable: true}
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK, ErrorResult: &failure}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Equalf(t, classify(err), want, "\n\n"+
+			"UT Name:  The error is classified by its status code, even when it has an error result.\n"+
+			"\033[32mExpected: %+v\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", want, classify(err))
+
+		assert.Truef(t, errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadGateway, "\n\n"+
+			"UT Name:  A 'rapi.StatusError' is returned, which wraps the decode error.\n"+
+			"\033[32mExpected: status code 502\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the connection is refused.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{})
+		srvFake.Close()
+
+		// ARRANGE.
+		var urlErr *url.Error
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Equalf(t, classify(err), Classification{Network: true, Retryable: true}, "\n\n"+
+			"UT Name:  A retryable network error is returned when the connection is refused.\n"+
+			"\033[32mExpected: %+v\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", Classification{Network: true, Retryable: true}, classify(err))
+
+		assert.Truef(t, errors.As(err, &urlErr), "\n\n"+
+			"UT Name:  The original '*url.Error' is preserved.\n"+
+			"\033[32mExpected: *url.Error\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, strings.HasPrefix(err.Error(), "GET "+srvFake.URL()+": "), "\n\n"+
+			"UT Name:  The error message contains the method and the URL of the request.\n"+
+			"\033[32mExpected: GET %s: ...\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", srvFake.URL(), err)
+	})
+
+	t.Run("When the request times out.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		client := &http.Client{Timeout: 20 * time.Millisecond}
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(client, nil)
+
+		// ASSERT.
+		assert.Truef(t, rapi.IsTimeout(err) && rapi.IsRetryable(err), "\n\n"+
+			"UT Name:  A retryable timeout is returned when the request times out.\n"+
+			"\033[32mExpected: timeout\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the URL contains a password.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusBadRequest},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		endpoint := strings.Replace(srvFake.URL(), "://", "://user:secret@", 1)
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: endpoint, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Falsef(t, strings.Contains(err.Error(), "secret"), "\n\n"+
+			"UT Name:  The password in the URL is redacted from the error message.\n"+
+			"\033[32mExpected: user:xxxxx\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
@@ -0,0 +1,177 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrNoFutures is returned when waiting for any of the calls of no futures.
+var ErrNoFutures = errors.New("no futures")
+
+// Future describes the outcome of a call which is made asynchronously, such as an HTTP request.
+type Future[T any] struct {
+	done   chan struct{}      // Closed when the call is completed.
+	cancel context.CancelFunc // Cancels the context of the call.
+	result T                  // The result of the call.
+	err    error              // The error of the call.
+}
+
+// Async makes call asynchronously with a context derived from ctx, and returns its future.
+// Cancelling the future cancels that context, which aborts the HTTP requests made with it.
+func Async[T any](ctx context.Context, call func(ctx context.Context) (T, error)) *Future[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	f := &Future[T]{done: make(chan struct{}), cancel: cancel}
+
+	go func() {
+		defer close(f.done)
+		defer cancel()
+
+		f.result, f.err = call(ctx)
+	}()
+
+	return f
+}
+
+// GETAsync uses client to make an HTTP GET request described by req asynchronously, and returns its future.
+// The request is made with a context derived from the one of req, so cancelling the future aborts it.
+func GETAsync[T any](client *http.Client, req *GETRequestMsg) *Future[T] {
+	call := *req
+
+	return Async(req.context(), func(ctx context.Context) (T, error) {
+		var result T
+
+		call.Context = ctx
+		err := call.GET(client, &result)
+
+		return result, err
+	})
+}
+
+// Done returns a channel which is closed when the call of f is completed.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel cancels the call of f. It doesn't wait until the call is completed.
+func (f *Future[T]) Cancel() {
+	f.cancel()
+}
+
+// Wait waits until the call of f is completed and returns its result.
+// It returns the error of the call.
+func (f *Future[T]) Wait() (T, error) {
+	<-f.done
+
+	return f.result, f.err
+}
+
+// WaitAll waits until the calls of futures are completed and returns their results, in the order of futures.
+// When a call fails, the other ones are canceled and its error is returned.
+func WaitAll[T any](futures ...*Future[T]) ([]T, error) {
+	completed := notify(futures)
+
+	for range futures {
+		if f := <-completed; f.err != nil {
+			cancelAll(futures)
+
+			return nil, f.err
+		}
+	}
+
+	results := make([]T, len(futures))
+
+	for i, f := range futures {
+		results[i] = f.result
+	}
+
+	return results, nil
+}
+
+// WaitAny waits until the first call of futures is completed, cancels the other ones and returns its result.
+// It returns the error of that call, or ErrNoFutures when there are no futures.
+func WaitAny[T any](futures ...*Future[T]) (T, error) {
+	if len(futures) == 0 {
+		var zero T
+
+		return zero, ErrNoFutures
+	}
+
+	f := <-notify(futures)
+	cancelAll(futures)
+
+	return f.result, f.err
+}
+
+// WaitFirstSuccess waits until the first call of futures succeeds, cancels the other ones and returns its result.
+// When all the calls fail, their errors are returned.
+func WaitFirstSuccess[T any](futures ...*Future[T]) (T, error) {
+	completed := notify(futures)
+	errs := make([]error, 0, len(futures))
+
+	for range futures {
+		f := <-completed
+
+		if f.err == nil {
+			cancelAll(futures)
+
+			return f.result, nil
+		}
+
+		errs = append(errs, f.err)
+	}
+
+	var zero T
+
+	if len(errs) == 0 {
+		return zero, ErrNoFutures
+	}
+
+	return zero, errors.Join(errs...)
+}
+
+// Returns a channel which receives the futures in the order in which their calls are completed.
+func notify[T any](futures []*Future[T]) <-chan *Future[T] {
+	completed := make(chan *Future[T], len(futures))
+
+	for _, f := range futures {
+		go func() {
+			<-f.done
+			completed <- f
+		}()
+	}
+
+	return completed
+}
+
+// Cancels the calls of futures.
+func cancelAll[T any](futures []*Future[T]) {
+	for _, f := range futures {
+		f.Cancel()
+	}
+}
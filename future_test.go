@@ -0,0 +1,287 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// Returns a server which responds to "/<value>/<status>" with status and value, and which never responds to "/block".
+// The requests to "/block" are counted in started, and the ones which are aborted in aborted.
+func newFutureServer(started, aborted *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/{value}/{status}", func(w http.ResponseWriter, r *http.Request) {
+		status, _ := strconv.Atoi(r.PathValue("status"))
+
+		w.WriteHeader(status)
+		w.Write([]byte(r.PathValue("value")))
+	})
+
+	mux.HandleFunc("/block", func(w http.ResponseWriter, r *http.Request) {
+		started.Add(1)
+		<-r.Context().Done()
+		aborted.Add(1)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// UT: Make HTTP requests asynchronously.
+func TestFuture(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Returns the future of a GET request to "/<value>/<status>" on srv.
+	get := func(srv *httptest.Server, value, status int) *rapi.Future[int] {
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srv.URL + "/" + strconv.Itoa(value) + "/" + strconv.Itoa(status),
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		return rapi.GETAsync[int](http.DefaultClient, &request)
+	}
+
+	// Returns the future of a GET request to "/block" on srv, which never completes unless it's aborted.
+	block := func(srv *httptest.Server) *rapi.Future[int] {
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srv.URL + "/block", OkStatusCode: http.StatusOK}}
+
+		return rapi.GETAsync[int](http.DefaultClient, &request)
+	}
+
+	t.Run("When the future is awaited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		f := get(srvFake, 10, http.StatusOK)
+
+		// ACT.
+		<-f.Done()
+		got, err := f.Wait()
+
+		// ASSERT.
+		assert.Truef(t, err == nil && got == 10, "\n\n"+
+			"UT Name:  The result of the request is returned when the future is awaited.\n"+
+			"\033[32mExpected: 10 <nil>\033[0m\n"+
+			"\033[31mActual:   %d %v\033[0m\n\n", got, err)
+	})
+
+	t.Run("When the future is canceled.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		f := block(srvFake)
+
+		// ACT.
+		waitFor(func() bool { return started.Load() == 1 })
+		f.Cancel()
+		_, err := f.Wait()
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, context.Canceled), "\n\n"+
+			"UT Name:  'context.Canceled' is returned when the future is canceled.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", context.Canceled, err)
+	})
+
+	t.Run("When all the futures are awaited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		futures := []*rapi.Future[int]{get(srvFake, 30, http.StatusOK), get(srvFake, 10, http.StatusOK), get(srvFake, 20, http.StatusOK)}
+
+		// ACT.
+		got, err := rapi.WaitAll(futures...)
+
+		// ASSERT.
+		assert.Truef(t, err == nil && len(got) == 3 && got[0] == 30 && got[1] == 10 && got[2] == 20, "\n\n"+
+			"UT Name:  The results are returned in the order of the futures.\n"+
+			"\033[32mExpected: [30 10 20] <nil>\033[0m\n"+
+			"\033[31mActual:   %v %v\033[0m\n\n", got, err)
+	})
+
+	t.Run("When all the futures are awaited, and one fails.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		slow := block(srvFake)
+		waitFor(func() bool { return started.Load() == 1 })
+
+		// ACT.
+		_, err := rapi.WaitAll(slow, get(srvFake, 10, http.StatusBadRequest))
+		slow.Wait()
+
+		// ASSERT.
+		assert.Truef(t, rapi.IsClientError(err), "\n\n"+
+			"UT Name:  The 'error' of the failed call is returned.\n"+
+			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, waitFor(func() bool { return aborted.Load() == 1 }), "\n\n"+
+			"UT Name:  The other calls are aborted when a call fails.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", aborted.Load())
+	})
+
+	t.Run("When any of the futures is awaited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		slow := block(srvFake)
+		waitFor(func() bool { return started.Load() == 1 })
+
+		futures := []*rapi.Future[int]{slow, get(srvFake, 10, http.StatusBadRequest)}
+
+		// ACT.
+		_, err := rapi.WaitAny(futures...)
+
+		// ASSERT.
+		assert.Truef(t, rapi.IsClientError(err), "\n\n"+
+			"UT Name:  The outcome of the first completed call is returned.\n"+
+			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, waitFor(func() bool { return aborted.Load() == 1 }), "\n\n"+
+			"UT Name:  The other calls are aborted.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", aborted.Load())
+	})
+
+	t.Run("When the first success of the futures is awaited.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		slow := block(srvFake)
+		waitFor(func() bool { return started.Load() == 1 })
+
+		futures := []*rapi.Future[int]{slow, get(srvFake, 10, http.StatusBadRequest), get(srvFake, 30, http.StatusOK)}
+
+		// ACT.
+		got, err := rapi.WaitFirstSuccess(futures...)
+
+		// ASSERT.
+		assert.Truef(t, err == nil && got == 30, "\n\n"+
+			"UT Name:  The result of the first successful call is returned.\n"+
+			"\033[32mExpected: 30 <nil>\033[0m\n"+
+			"\033[31mActual:   %d %v\033[0m\n\n", got, err)
+
+		assert.Truef(t, waitFor(func() bool { return aborted.Load() == 1 }), "\n\n"+
+			"UT Name:  The other calls are aborted.\n"+
+			"\033[32mExpected: 1\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", aborted.Load())
+	})
+
+	t.Run("When all the futures fail.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var started, aborted atomic.Int32
+
+		srvFake := newFutureServer(&started, &aborted)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		futures := []*rapi.Future[int]{get(srvFake, 10, http.StatusBadRequest), get(srvFake, 20, http.StatusBadGateway)}
+
+		// ACT.
+		_, err := rapi.WaitFirstSuccess(futures...)
+
+		// ASSERT.
+		assert.Truef(t, err != nil && strings.Contains(err.Error(), "status code 400") && strings.Contains(err.Error(), "status code 502"), "\n\n"+
+			"UT Name:  The errors of all the calls are returned when they all fail.\n"+
+			"\033[32mExpected: status code 400, status code 502\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
+
+// Reports whether condition becomes true within a second.
+func waitFor(condition func() bool) bool {
+	for range 100 {
+		if condition() {
+			return true
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
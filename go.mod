@@ -5,4 +5,5 @@ go 1.24.3
 require (
 	github.com/go-essentials/assert v1.1.1
 	github.com/go-essentials/tstsrv v1.1.0
+	github.com/klauspost/compress v1.18.0
 )
@@ -0,0 +1,116 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"crypto/rand"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// Idempotency describes how an HTTP request sends an idempotency key, so the server can detect its retries and
+// respond to them without performing the request again (see the IETF draft "The Idempotency-Key HTTP Header Field").
+// A key is generated for every call, and shared by all the attempts of that call. A key which is set in the headers
+// of the request is used instead, so a caller can retry a call with the same key.
+type Idempotency struct {
+	Header         string                 // The header which contains the key. Defaults to "Idempotency-Key".
+	ReplayedHeader string                 // The header which reports a replayed response. Defaults to "Idempotent-Replayed".
+	Generate       func() (string, error) // Generates a key. Defaults to NewIdempotencyKey.
+}
+
+// NewIdempotencyKey returns a random (version 4) UUID, to be used as an idempotency key.
+// It returns an error if the random bytes can't be read.
+func NewIdempotencyKey() (string, error) {
+	var uuid [16]byte
+
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+}
+
+// Returns the header which contains the key.
+func (i *Idempotency) header() string {
+	if i == nil || i.Header == "" {
+		return "Idempotency-Key"
+	}
+
+	return i.Header
+}
+
+// Returns the header which reports a replayed response.
+func (i *Idempotency) replayedHeader() string {
+	if i == nil || i.ReplayedHeader == "" {
+		return "Idempotent-Replayed"
+	}
+
+	return i.ReplayedHeader
+}
+
+// Returns a copy of req which sends an idempotency key generated for this call, or req itself when it doesn't send an
+// idempotency key or when its headers already contain one.
+// It returns the error of Idempotency.Generate when the key can't be generated.
+func (req *BaseRequest) idempotent() (*BaseRequest, error) {
+	if req.Idempotency == nil {
+		return req, nil
+	}
+
+	header := http.CanonicalHeaderKey(req.Idempotency.header())
+
+	for key := range req.HttpHeaders {
+		if http.CanonicalHeaderKey(key) == header {
+			return req, nil
+		}
+	}
+
+	generate := req.Idempotency.Generate
+
+	if generate == nil {
+		generate = NewIdempotencyKey
+	}
+
+	key, err := generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	base := *req
+	base.HttpHeaders = maps.Clone(req.HttpHeaders)
+
+	if base.HttpHeaders == nil {
+		base.HttpHeaders = make(map[string]string)
+	}
+
+	base.HttpHeaders[header] = key
+
+	return &base, nil
+}
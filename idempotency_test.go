@@ -0,0 +1,198 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// Returns a server which records the idempotency keys it receives in header, rejects the first request with a 401
+// when it's authenticated, and reports the responses to keys it already received as replayed.
+func newIdempotencyServer(header string, keys *[]string, lock *sync.Mutex) *httptest.Server {
+	var rejected atomic.Bool
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		key := r.Header.Get(header)
+
+		for _, received := range *keys {
+			if received == key {
+				w.Header().Set("Idempotent-Replayed", "true")
+			}
+		}
+
+		*keys = append(*keys, key)
+
+		if r.Header.Get("Authorization") != "" && !rejected.Swap(true) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	}))
+}
+
+// UT: Send idempotency keys with HTTP requests.
+func TestIdempotency(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Key      string `rapi:"idempotency-key"`
+		Replayed bool   `rapi:"replayed"`
+	}
+
+	t.Run("When the request is replayed.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var keys []string
+		var lock sync.Mutex
+		var count atomic.Int32
+
+		srvFake := newIdempotencyServer("Idempotency-Key", &keys, &lock)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&count)},
+				Idempotency:  &rapi.Idempotency{},
+			},
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the request is replayed.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, len(keys) == 2 && keys[0] == keys[1] && keys[0] == got.Key, "\n\n"+
+			"UT Name:  All the attempts of a call share the same idempotency key.\n"+
+			"\033[32mExpected: [%s %s]\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", got.Key, got.Key, keys)
+
+		assert.Truef(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(got.Key), "\n\n"+
+			"UT Name:  The idempotency key is a random UUID.\n"+
+			"\033[32mExpected: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Key)
+
+		assert.Truef(t, got.Replayed, "\n\n"+
+			"UT Name:  The replayed response is reported.\n"+
+			"\033[32mExpected: true\033[0m\n"+
+			"\033[31mActual:   %t\033[0m\n\n", got.Replayed)
+	})
+
+	t.Run("When the request is made twice.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var keys []string
+		var lock sync.Mutex
+
+		srvFake := newIdempotencyServer("X-Request-Key", &keys, &lock)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var first, second Response
+		var count atomic.Int32
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Idempotency: &rapi.Idempotency{
+					Header:   "X-Request-Key",
+					Generate: newTokenFetcher(&count),
+				},
+			},
+		}
+
+		// ACT.
+		request.POST(http.DefaultClient, &first)
+		request.POST(http.DefaultClient, &second)
+
+		// ASSERT.
+		assert.Truef(t, first.Key == "token-1" && second.Key == "token-2" && !second.Replayed, "\n\n"+
+			"UT Name:  Every call has its own idempotency key, generated by the generator.\n"+
+			"\033[32mExpected: token-1 token-2\033[0m\n"+
+			"\033[31mActual:   %s %s\033[0m\n\n", first.Key, second.Key)
+	})
+
+	t.Run("When the idempotency key is set in the headers.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var keys []string
+		var lock sync.Mutex
+
+		srvFake := newIdempotencyServer("Idempotency-Key", &keys, &lock)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var first, second Response
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				HttpHeaders:  map[string]string{"idempotency-key": "key"},
+				Idempotency:  &rapi.Idempotency{},
+			},
+		}
+
+		// ACT.
+		request.POST(http.DefaultClient, &first)
+		request.POST(http.DefaultClient, &second)
+
+		// ASSERT.
+		assert.Truef(t, first.Key == "key" && second.Key == "key" && second.Replayed, "\n\n"+
+			"UT Name:  The idempotency key in the headers is used for every call.\n"+
+			"\033[32mExpected: key key (replayed)\033[0m\n"+
+			"\033[31mActual:   %s %s (%t)\033[0m\n\n", first.Key, second.Key, second.Replayed)
+	})
+}
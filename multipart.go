@@ -0,0 +1,204 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Multipart describes a multipart/form-data payload.
+// The parts are streamed while the request is sent, so large files are never fully buffered. The payload can be
+// replayed (e.g. when the request is re-authenticated) as long as all the sources of its parts can be replayed.
+type Multipart struct {
+	parts    []multipartPart // The parts of the payload.
+	boundary string          // The boundary between the parts.
+	once     sync.Once       // Generate the boundary only once.
+}
+
+// A part of a multipart/form-data payload.
+type multipartPart struct {
+	header textproto.MIMEHeader      // The header of the part.
+	open   func() (io.Reader, error) // Returns the content of the part.
+}
+
+// AddField adds a text field named name to m.
+func (m *Multipart) AddField(name, value string) *Multipart {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(name)))
+
+	return m.add(header, func() (io.Reader, error) {
+		return strings.NewReader(value), nil
+	})
+}
+
+// AddJSON adds a field named name, containing the JSON encoding of v, to m.
+// It returns an error if v can't be encoded.
+func (m *Multipart) AddJSON(name string, v any) error {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return fmt.Errorf("failed to encode part %q: %w", name, err)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(name)))
+	header.Set("Content-Type", "application/json")
+
+	m.add(header, func() (io.Reader, error) {
+		return strings.NewReader(string(data)), nil
+	})
+
+	return nil
+}
+
+// AddFile adds a file named filename, with contentType, to m as a field named name. The content is read from source.
+// When source is an io.Seeker, it's rewound when the payload is replayed. Otherwise, the payload can't be replayed.
+// The caller remains responsible for closing source.
+func (m *Multipart) AddFile(name, filename, contentType string, source io.Reader) *Multipart {
+	consumed := false
+
+	return m.AddFileFunc(name, filename, contentType, func() (io.Reader, error) {
+		if seeker, ok := source.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("failed to rewind file %q: %w", filename, err)
+			}
+		} else if consumed {
+			return nil, fmt.Errorf("file %q can't be replayed", filename)
+		}
+
+		consumed = true
+
+		return struct{ io.Reader }{source}, nil
+	})
+}
+
+// AddFileFunc adds a file named filename, with contentType, to m as a field named name. The content is returned by
+// open, which is invoked each time the payload is sent, so the payload can be replayed.
+// When the returned reader is an io.Closer, it's closed once it's consumed.
+func (m *Multipart) AddFileFunc(name, filename, contentType string, open func() (io.Reader, error)) *Multipart {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(name), escapeQuotes(filename)))
+	header.Set("Content-Type", contentType)
+
+	return m.add(header, open)
+}
+
+// ContentType returns the content type of m, including its boundary.
+func (m *Multipart) ContentType() string {
+	return "multipart/form-data; boundary=" + m.getBoundary()
+}
+
+// Adds a part, with header and content returned by open, to m.
+func (m *Multipart) add(header textproto.MIMEHeader, open func() (io.Reader, error)) *Multipart {
+	m.parts = append(m.parts, multipartPart{header: header, open: open})
+
+	return m
+}
+
+// Returns the boundary of m, which is generated the first time it's requested.
+func (m *Multipart) getBoundary() string {
+	m.once.Do(func() {
+		m.boundary = multipart.NewWriter(io.Discard).Boundary()
+	})
+
+	return m.boundary
+}
+
+// Returns m as the payload of an HTTP request.
+func (m *Multipart) payload() *payload {
+	return &payload{
+		open: func() (io.Reader, error) {
+			reader, writer := io.Pipe()
+
+			go func() {
+				writer.CloseWithError(m.write(writer))
+			}()
+
+			return reader, nil
+		},
+		contentType: m.ContentType(),
+	}
+}
+
+// Writes the parts of m to w.
+// It returns an error if a part can't be read or written.
+func (m *Multipart) write(w io.Writer) error {
+	writer := multipart.NewWriter(w)
+
+	if err := writer.SetBoundary(m.getBoundary()); err != nil {
+		return err
+	}
+
+	for _, part := range m.parts {
+		if err := writePart(writer, part); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// Writes part to writer.
+// It returns an error if the part can't be read or written.
+func writePart(writer *multipart.Writer, part multipartPart) error {
+	content, err := part.open()
+
+	if err != nil {
+		return err
+	}
+
+	if closer, ok := content.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	partWriter, err := writer.CreatePart(part.header)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(partWriter, content); err != nil {
+		return fmt.Errorf("failed to write part: %w", err)
+	}
+
+	return nil
+}
+
+// Returns s with its quotes and backslashes escaped.
+func escapeQuotes(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
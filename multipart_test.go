@@ -0,0 +1,237 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// The parts of a multipart/form-data request, as received by a multipart server.
+type receivedParts map[string]string
+
+// Returns a server which responds with the JSON encoded parts of each multipart/form-data request. Each part is
+// described as "<filename>|<content type>|<content>". The first rejected requests are rejected with a 401.
+func newMultipartServer(rejected int32) *httptest.Server {
+	var count atomic.Int32
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= rejected {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		reader, err := r.MultipartReader()
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		parts := make(receivedParts)
+
+		for part, err := reader.NextPart(); err == nil; part, err = reader.NextPart() {
+			content, _ := io.ReadAll(part)
+			parts[part.FormName()] = part.FileName() + "|" + part.Header.Get("Content-Type") + "|" + string(content)
+		}
+
+		json.NewEncoder(w).Encode(parts)
+	}))
+}
+
+// UT: Make an HTTP POST request with a multipart/form-data payload.
+func TestMultipart(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the payload has fields, files and JSON parts.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newMultipartServer(0)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got receivedParts
+
+		payload := new(rapi.Multipart).
+			AddField("title", "Report").
+			AddFile("document", "report.txt", "text/plain", io.LimitReader(strings.NewReader("HELLO, WORLD!"), 5))
+
+		payload.AddJSON("metadata", map[string]string{"owner": "kdeconinck"})
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK},
+			Multipart:   payload,
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the multipart payload is valid.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got["title"], "||Report", "\n\n"+
+			"UT Name:  The text field is sent.\n"+
+			"\033[32mExpected: ||Report\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got["title"])
+
+		assert.Equalf(t, got["document"], "report.txt|text/plain|HELLO", "\n\n"+
+			"UT Name:  The file is sent.\n"+
+			"\033[32mExpected: report.txt|text/plain|HELLO\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got["document"])
+
+		assert.Equalf(t, got["metadata"], `|application/json|{"owner":"kdeconinck"}`, "\n\n"+
+			"UT Name:  The JSON part is sent.\n"+
+			"\033[32mExpected: |application/json|{\"owner\":\"kdeconinck\"}\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got["metadata"])
+	})
+
+	t.Run("When the payload is replayed with a seekable file.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newMultipartServer(1)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got receivedParts
+		var fetches atomic.Int32
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches)},
+			},
+			Multipart: new(rapi.Multipart).AddFile("document", "report.txt", "", strings.NewReader("HELLO")),
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the payload can be replayed.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got["document"], "report.txt|application/octet-stream|HELLO", "\n\n"+
+			"UT Name:  The file is sent again when the payload is replayed.\n"+
+			"\033[32mExpected: report.txt|application/octet-stream|HELLO\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got["document"])
+	})
+
+	t.Run("When the payload is replayed with a file that can't be replayed.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newMultipartServer(1)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got receivedParts
+		var fetches atomic.Int32
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Auth:         &rapi.TokenAuth{Fetch: newTokenFetcher(&fetches)},
+			},
+			Multipart: new(rapi.Multipart).AddFile("document", "report.txt", "", io.LimitReader(strings.NewReader("HELLO"), 5)),
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when the payload can't be replayed.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
+
+// UT: Release multipart/form-data payloads which aren't sent.
+// Not parallel, since it counts the goroutines of the process.
+func TestMultipartNotSent(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		compression *rapi.Compression
+	}{
+		{name: "When the authentication of the request fails."},
+		{name: "When the authentication of a compressed request fails.", compression: &rapi.Compression{Encoding: "gzip"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE.
+			before := runtime.NumGoroutine()
+
+			request := rapi.POSTRequestMsg{
+				BaseRequest: rapi.BaseRequest{
+					Endpoint:     "http://localhost",
+					OkStatusCode: http.StatusOK,
+					Compression:  tc.compression,
+					Auth: &rapi.TokenAuth{
+						Fetch: func() (string, error) { return "", errors.New("no token") },
+					},
+				},
+				Multipart: &rapi.Multipart{},
+			}
+
+			request.Multipart.AddField("name", strings.Repeat("rapi", 1024))
+
+			// ACT.
+			for range 20 {
+				request.POST(http.DefaultClient, nil)
+			}
+
+			// ASSERT.
+			assert.Truef(t, waitFor(func() bool { return runtime.NumGoroutine() <= before }), "\n\n"+
+				"UT Name:  The goroutines writing the payload stop when the request isn't sent.\n"+
+				"\033[32mExpected: <= %d goroutines\033[0m\n"+
+				"\033[31mActual:   %d goroutines\033[0m\n\n", before, runtime.NumGoroutine())
+		})
+	}
+}
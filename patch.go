@@ -0,0 +1,277 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The media types of JSON Patch and JSON Merge Patch documents.
+const (
+	JSONPatchContentType  = "application/json-patch+json"  // The media type of a JSON Patch document (see RFC 6902).
+	MergePatchContentType = "application/merge-patch+json" // The media type of a JSON Merge Patch document (see RFC 7396).
+)
+
+// PatchOperation describes an operation of a JSON Patch document (see RFC 6902).
+type PatchOperation struct {
+	Op    string // The operation: "add", "remove", "replace", "move", "copy" or "test".
+	Path  string // The JSON Pointer to the target location (see RFC 6901).
+	From  string // The JSON Pointer to the source location of a "move" or "copy" operation.
+	Value any    // The value of an "add", "replace" or "test" operation.
+}
+
+// MarshalJSON returns the JSON encoding of op. The value is only encoded for the operations which have one, even when
+// it's <nil>, which is encoded as null.
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	type operation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		From  string `json:"from,omitempty"`
+		Value *any   `json:"value,omitempty"`
+	}
+
+	encoded := operation{Op: op.Op, Path: op.Path, From: op.From}
+
+	if op.Op == "add" || op.Op == "replace" || op.Op == "test" {
+		encoded.Value = &op.Value
+	}
+
+	return json.Marshal(encoded)
+}
+
+// JSONPatch describes a JSON Patch document (see RFC 6902), which is built by chaining its methods, such as
+// JSONPatch{}.Replace("/name", "rapi").Remove("/tags/0").
+type JSONPatch []PatchOperation
+
+// Add returns p with an operation which adds value at path.
+func (p JSONPatch) Add(path string, value any) JSONPatch {
+	return append(p, PatchOperation{Op: "add", Path: path, Value: value})
+}
+
+// Remove returns p with an operation which removes the value at path.
+func (p JSONPatch) Remove(path string) JSONPatch {
+	return append(p, PatchOperation{Op: "remove", Path: path})
+}
+
+// Replace returns p with an operation which replaces the value at path with value.
+func (p JSONPatch) Replace(path string, value any) JSONPatch {
+	return append(p, PatchOperation{Op: "replace", Path: path, Value: value})
+}
+
+// Move returns p with an operation which moves the value at from to path.
+func (p JSONPatch) Move(from, path string) JSONPatch {
+	return append(p, PatchOperation{Op: "move", Path: path, From: from})
+}
+
+// Copy returns p with an operation which copies the value at from to path.
+func (p JSONPatch) Copy(from, path string) JSONPatch {
+	return append(p, PatchOperation{Op: "copy", Path: path, From: from})
+}
+
+// Test returns p with an operation which tests that the value at path equals value.
+func (p JSONPatch) Test(path string, value any) JSONPatch {
+	return append(p, PatchOperation{Op: "test", Path: path, Value: value})
+}
+
+// JSONPointer returns the JSON Pointer (see RFC 6901) which consists of tokens, such as "/tags/0" for "tags" and "0".
+func JSONPointer(tokens ...string) string {
+	var pointer strings.Builder
+
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+
+	return pointer.String()
+}
+
+// DiffJSONPatch returns the JSON Patch document which transforms the JSON encoding of original into the one of
+// modified. Objects are compared member by member, and arrays element by element.
+// It returns an error if original or modified can't be encoded as JSON.
+func DiffJSONPatch(original, modified any) (JSONPatch, error) {
+	from, to, err := normalizeJSON(original, modified)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return diffJSONPatch(JSONPatch{}, "", from, to), nil
+}
+
+// DiffMergePatch returns the JSON Merge Patch document which transforms the JSON encoding of original into the one of
+// modified. Members which are removed are set to null, and arrays are replaced as a whole.
+// It returns an error if original or modified can't be encoded as JSON.
+func DiffMergePatch(original, modified any) (json.RawMessage, error) {
+	from, to, err := normalizeJSON(original, modified)
+
+	if err != nil {
+		return nil, err
+	}
+
+	patch, _ := diffMergePatch(from, to)
+
+	data, err := json.Marshal(patch)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return data, nil
+}
+
+// Returns the generic JSON representations of the values, with numbers as json.Number so they are compared and
+// encoded without losing precision.
+// It returns an error if a value can't be encoded as JSON.
+func normalizeJSON(values ...any) (any, any, error) {
+	normalized := make([]any, len(values))
+
+	for i, value := range values {
+		data, err := json.Marshal(value)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&normalized[i]); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+	}
+
+	return normalized[0], normalized[1], nil
+}
+
+// Returns patch with the operations which transform from, at path, into to.
+func diffJSONPatch(patch JSONPatch, path string, from, to any) JSONPatch {
+	fromObject, fromIsObject := from.(map[string]any)
+	toObject, toIsObject := to.(map[string]any)
+
+	if fromIsObject && toIsObject {
+		for _, key := range slices.Sorted(maps.Keys(fromObject)) {
+			if _, found := toObject[key]; !found {
+				patch = patch.Remove(path + JSONPointer(key))
+			}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(toObject)) {
+			if value, found := fromObject[key]; found {
+				patch = diffJSONPatch(patch, path+JSONPointer(key), value, toObject[key])
+			} else {
+				patch = patch.Add(path+JSONPointer(key), toObject[key])
+			}
+		}
+
+		return patch
+	}
+
+	fromArray, fromIsArray := from.([]any)
+	toArray, toIsArray := to.([]any)
+
+	if fromIsArray && toIsArray {
+		for i := range min(len(fromArray), len(toArray)) {
+			patch = diffJSONPatch(patch, path+JSONPointer(strconv.Itoa(i)), fromArray[i], toArray[i])
+		}
+
+		for i := len(fromArray) - 1; i >= len(toArray); i-- {
+			patch = patch.Remove(path + JSONPointer(strconv.Itoa(i)))
+		}
+
+		for i := len(fromArray); i < len(toArray); i++ {
+			patch = patch.Add(path+JSONPointer(strconv.Itoa(i)), toArray[i])
+		}
+
+		return patch
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		patch = patch.Replace(path, to)
+	}
+
+	return patch
+}
+
+// Returns the merge patch which transforms from into to. It reports whether from and to differ.
+func diffMergePatch(from, to any) (any, bool) {
+	fromObject, fromIsObject := from.(map[string]any)
+	toObject, toIsObject := to.(map[string]any)
+
+	if !fromIsObject || !toIsObject {
+		return to, !reflect.DeepEqual(from, to)
+	}
+
+	patch := make(map[string]any)
+
+	for key := range fromObject {
+		if _, found := toObject[key]; !found {
+			patch[key] = nil
+		}
+	}
+
+	for key, value := range toObject {
+		if original, found := fromObject[key]; !found {
+			patch[key] = value
+		} else if member, changed := diffMergePatch(original, value); changed {
+			patch[key] = member
+		}
+	}
+
+	return patch, len(patch) > 0
+}
+
+// Returns the payload of req: its JSON Patch or JSON Merge Patch document when it has one, or its payload otherwise.
+// It returns an error if the document can't be encoded as JSON.
+func (req *PATCHRequestMsg) payload() (*payload, error) {
+	var document any
+	var contentType string
+
+	switch {
+	case req.JSONPatch != nil:
+		document, contentType = req.JSONPatch, JSONPatchContentType
+
+	case req.MergePatch != nil:
+		document, contentType = req.MergePatch, MergePatchContentType
+
+	default:
+		return textPayload(req.Payload, ""), nil
+	}
+
+	data, err := json.Marshal(document)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return textPayload(string(data), contentType), nil
+}
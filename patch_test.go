@@ -0,0 +1,194 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// A resource to patch.
+type patchedResource struct {
+	Name  string            `json:"name"`
+	Tags  []string          `json:"tags"`
+	Owner *string           `json:"owner,omitempty"`
+	Meta  map[string]string `json:"meta,omitempty"`
+}
+
+// UT: Build JSON Patch documents.
+func TestJSONPatch(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the operations are chained.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		patch := rapi.JSONPatch{}.
+			Test(rapi.JSONPointer("a/b", "c~d"), nil).
+			Add("/tags/-", "new").
+			Remove("/owner").
+			Replace("/name", "rapi").
+			Move("/from", "/to").
+			Copy("/from", "/copy")
+
+		// ACT.
+		data, err := json.Marshal(patch)
+
+		// ASSERT.
+		want := `[{"op":"test","path":"/a~1b/c~0d","value":null},{"op":"add","path":"/tags/-","value":"new"},` +
+			`{"op":"remove","path":"/owner"},{"op":"replace","path":"/name","value":"rapi"},` +
+			`{"op":"move","path":"/to","from":"/from"},{"op":"copy","path":"/copy","from":"/from"}]`
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the JSON Patch document is encoded.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, string(data), want, "\n\n"+
+			"UT Name:  The JSON Patch document is encoded as defined by RFC 6902.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, data)
+	})
+
+	t.Run("When two values are compared.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		owner := "kevin"
+		original := patchedResource{Name: "rapi", Tags: []string{"go", "http", "json"}, Owner: &owner}
+		modified := patchedResource{Name: "rapi", Tags: []string{"go", "rest"}, Meta: map[string]string{"a/b": "c"}}
+
+		// ACT.
+		patch, err := rapi.DiffJSONPatch(original, modified)
+		data, _ := json.Marshal(patch)
+
+		// ASSERT.
+		want := `[{"op":"remove","path":"/owner"},{"op":"add","path":"/meta","value":{"a/b":"c"}},` +
+			`{"op":"replace","path":"/tags/1","value":"rest"},{"op":"remove","path":"/tags/2"}]`
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when two values are compared.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, string(data), want, "\n\n"+
+			"UT Name:  The JSON Patch document transforms the original value into the modified one.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, data)
+	})
+}
+
+// UT: Build JSON Merge Patch documents.
+func TestDiffMergePatch(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When two values are compared.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		owner := "kevin"
+		original := patchedResource{Name: "rapi", Tags: []string{"go"}, Owner: &owner, Meta: map[string]string{"a": "1", "b": "2"}}
+		modified := patchedResource{Name: "rapi", Tags: []string{"go", "http"}, Meta: map[string]string{"a": "1", "b": "3"}}
+
+		// ACT.
+		patch, err := rapi.DiffMergePatch(original, modified)
+
+		// ASSERT.
+		want := `{"meta":{"b":"3"},"owner":null,"tags":["go","http"]}`
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when two values are compared.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, string(patch), want, "\n\n"+
+			"UT Name:  The JSON Merge Patch document transforms the original value into the modified one.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, patch)
+	})
+}
+
+// UT: Make an HTTP PATCH request with a patch document.
+func TestPATCHDocument(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for _, tc := range []struct {
+		name        string
+		request     rapi.PATCHRequestMsg
+		contentType string
+		body        string
+	}{
+		{
+			name:        "When the request has a JSON Patch document.",
+			request:     rapi.PATCHRequestMsg{JSONPatch: rapi.JSONPatch{}.Remove("/owner")},
+			contentType: rapi.JSONPatchContentType,
+			body:        `[{"op":"remove","path":"/owner"}]`,
+		},
+		{
+			name:        "When the request has a JSON Merge Patch document.",
+			request:     rapi.PATCHRequestMsg{MergePatch: map[string]any{"owner": nil}},
+			contentType: rapi.MergePatchContentType,
+			body:        `{"owner":null}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// FAKE SETUP.
+			var count atomic.Int32
+
+			srvFake := newEchoServer(&count)
+
+			defer srvFake.Close()
+
+			// ARRANGE.
+			var got echoedRequest
+
+			request := tc.request
+			request.BaseRequest = rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}
+
+			// ACT.
+			err := request.PATCH(http.DefaultClient, &got)
+
+			// ASSERT.
+			assert.Nilf(t, err, "\n\n"+
+				"UT Name:  NO 'error' is returned when the request has a patch document.\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", err)
+
+			assert.Truef(t, got.Headers["Content-Type"][0] == tc.contentType && got.Body == tc.body, "\n\n"+
+				"UT Name:  The patch document is sent with its media type.\n"+
+				"\033[32mExpected: %s %s\033[0m\n"+
+				"\033[31mActual:   %s %s\033[0m\n\n", tc.contentType, tc.body, got.Headers["Content-Type"], got.Body)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMissingLocation is returned when a long-running operation doesn't have the URL of its status.
+var ErrMissingLocation = errors.New("missing operation location")
+
+// Accepted is the result of an HTTP request which starts a long-running operation, and binds the URL of the status of
+// the operation from its 202 (Accepted) response.
+type Accepted struct {
+	OperationLocation string `rapi:"header:Operation-Location" json:"-"` // The Operation-Location header of the response.
+	Location          string `rapi:"header:Location" json:"-"`           // The Location header of the response.
+}
+
+// PollRequestMsg describes the polling of a long-running operation, which is started by an HTTP request that responds
+// with 202 (Accepted) and the URL of the status of the operation in its Operation-Location or Location header.
+// The status is polled with the context, the headers and the authentication of the "base" HTTP request. The URL of
+// the status can be bound from the 202 (Accepted) response using an Accepted (see NewPollRequestMsg).
+type PollRequestMsg struct {
+	BaseRequest                  // The "base" HTTP request, of which the Endpoint is the URL of the request which started the operation.
+	Location       string        // The URL of the status of the operation, relative to Endpoint.
+	Interval       time.Duration // The interval between the polls. Defaults to 1 second.
+	MaxInterval    time.Duration // The maximum interval between the polls. Zero is unlimited.
+	Backoff        float64       // The factor by which the interval grows after every poll, up to MaxInterval. Defaults to 1.
+	ResultLocation string        // The URL of the result, relative to Endpoint. Defaults to the Location header of the final status (relative to the URL of the status), or Endpoint.
+}
+
+// NewPollRequestMsg returns a PollRequestMsg which polls the operation started by the HTTP request described by base,
+// of which the status is located at the Operation-Location header of accepted or, when it doesn't have one, at its
+// Location header.
+func NewPollRequestMsg(base BaseRequest, accepted Accepted) *PollRequestMsg {
+	location := accepted.OperationLocation
+
+	if location == "" {
+		location = accepted.Location
+	}
+
+	return &PollRequestMsg{BaseRequest: base, Location: location}
+}
+
+// Poll uses client to poll the status of the long-running operation described by req, until done reports that it's
+// in a terminal state, and then fetches its result into result (unless result is <nil>). The status is decoded into a
+// S, which can bind the status code and the headers of the response as well. Every status and result response with
+// a 2xx status code is accepted, whatever the OK status codes of req (which describe the request that started the
+// operation). A Retry-After header in a status response takes precedence over the interval.
+// It returns the error returned by done, or the error of the context of req when it's canceled.
+func Poll[S any](client *http.Client, req *PollRequestMsg, done func(status *S) (bool, error), result any) error {
+	if req.Location == "" {
+		return req.wrapError(http.MethodGet, ErrMissingLocation)
+	}
+
+	location, err := resolveURL(req.Endpoint, req.Location)
+
+	if err != nil {
+		return req.wrapError(http.MethodGet, err)
+	}
+
+	interval := req.Interval
+
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	delay := interval
+
+	for {
+		if err := req.wait(delay); err != nil {
+			return req.wrapError(http.MethodGet, err)
+		}
+
+		var status S
+
+		statusReq := req.BaseRequest
+		statusReq.Endpoint, statusReq.Query = location, nil
+		statusReq.OkStatusCode, statusReq.OkStatusCodes = 0, StatusRange(200, 299)
+
+		responseData, response, err := statusReq.do(client, http.MethodGet, nil)
+
+		if err != nil {
+			return statusReq.wrapError(http.MethodGet, err)
+		}
+
+		if err := statusReq.decode(client, response, responseData, &status); err != nil {
+			return statusReq.wrapError(http.MethodGet, err)
+		}
+
+		finished, err := done(&status)
+
+		if err != nil {
+			return err
+		}
+
+		if finished {
+			return req.fetch(client, location, response.Header.Get("Location"), result)
+		}
+
+		interval = req.next(interval)
+		delay = interval
+
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+			delay = retryAfter
+		}
+	}
+}
+
+// Returns the interval which follows interval.
+func (req *PollRequestMsg) next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * max(req.Backoff, 1))
+
+	if req.MaxInterval > 0 {
+		next = min(next, req.MaxInterval)
+	}
+
+	return next
+}
+
+// Waits for delay, or until the context of req is canceled.
+// It returns the error of the context of req when it's canceled, or <nil> otherwise.
+func (req *PollRequestMsg) wait(delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+
+	case <-req.context().Done():
+		return req.context().Err()
+	}
+}
+
+// Uses client to fetch the result of the operation described by req into result. The result is located at
+// req.ResultLocation (relative to req.Endpoint), or at location (the Location header of the final status, relative
+// to statusURL) or at req.Endpoint, and every response with a 2xx status code is accepted.
+// It returns an error if the location of the result isn't a valid URL, or if the result can't be fetched.
+func (req *PollRequestMsg) fetch(client *http.Client, statusURL, location string, result any) error {
+	if result == nil {
+		return nil
+	}
+
+	resultReq := req.BaseRequest
+	resultReq.OkStatusCode, resultReq.OkStatusCodes = 0, StatusRange(200, 299)
+	base := statusURL
+
+	if req.ResultLocation != "" {
+		base, location = req.Endpoint, req.ResultLocation
+	}
+
+	if location != "" {
+		endpoint, err := resolveURL(base, location)
+
+		if err != nil {
+			return req.wrapError(http.MethodGet, err)
+		}
+
+		resultReq.Endpoint, resultReq.Query = endpoint, nil
+	}
+
+	return resultReq.exchange(client, http.MethodGet, nil, result)
+}
+
+// Returns reference resolved against base.
+// It returns an error if base or reference isn't a valid URL.
+func resolveURL(base, reference string) (string, error) {
+	baseURL, err := url.Parse(base)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	referenceURL, err := url.Parse(reference)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	return baseURL.ResolveReference(referenceURL).String(), nil
+}
+
+// Returns the delay described by the Retry-After header, which is a number of seconds or an HTTP date, relative to now.
+// It reports whether header is valid.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,246 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// The status of a long-running operation.
+type operationStatus struct {
+	Status string `json:"status"`
+}
+
+// Returns a server which starts an operation on "/jobs" (or "/exports", which only responds with a Location header),
+// which succeeds (or fails) after the given number of polls of its status, and counts the polls in count. The final
+// status locates the result relative to the status.
+func newOperationServer(polls int32, outcome string, count *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /jobs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Operation-Location", "/operations/1/status")
+		w.Header().Set("Location", "/jobs/1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("POST /exports", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/operations/1/status")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("GET /operations/1/status", func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) < polls {
+			w.Header().Set("Retry-After", "0")
+			w.Write([]byte(`{"status":"running"}`))
+
+			return
+		}
+
+		w.Header().Set("Location", "result")
+		w.Write([]byte(`{"status":"` + outcome + `"}`))
+	})
+
+	mux.HandleFunc("GET /operations/1/result", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1"}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// Reports whether the operation is finished, and returns an error when it failed.
+func operationDone(status *operationStatus) (bool, error) {
+	if status.Status == "failed" {
+		return true, errors.New("operation failed")
+	}
+
+	return status.Status == "succeeded", nil
+}
+
+// UT: Poll a long-running operation.
+func TestPoll(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Id string `json:"id"`
+	}
+
+	t.Run("When the operation succeeds.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newOperationServer(3, "succeeded", &count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var accepted rapi.Accepted
+		var got Response
+
+		start := rapi.POSTRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL + "/jobs", OkStatusCode: http.StatusAccepted}}
+		startErr := start.POST(http.DefaultClient, &accepted)
+
+		request := rapi.NewPollRequestMsg(start.BaseRequest, accepted)
+		request.Interval, request.Backoff = time.Millisecond, 2
+
+		// ACT.
+		err := rapi.Poll(http.DefaultClient, request, operationDone, &got)
+
+		// ASSERT.
+		assert.Nilf(t, errors.Join(startErr, err), "\n\n"+
+			"UT Name:  NO 'error' is returned when the operation succeeds.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", errors.Join(startErr, err))
+
+		assert.Equalf(t, count.Load(), int32(3), "\n\n"+
+			"UT Name:  The status is polled until the operation is finished.\n"+
+			"\033[32mExpected: 3\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+
+		assert.Equalf(t, got, Response{Id: "1"}, "\n\n"+
+			"UT Name:  The result of the operation is fetched, relative to the URL of the status.\n"+
+			"\033[32mExpected: {Id:1}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", got)
+	})
+
+	t.Run("When the operation is only located by a Location header.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newOperationServer(1, "succeeded", &count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var accepted rapi.Accepted
+		var got Response
+
+		start := rapi.POSTRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL + "/exports", OkStatusCode: http.StatusAccepted}}
+		startErr := start.POST(http.DefaultClient, &accepted)
+
+		request := rapi.NewPollRequestMsg(start.BaseRequest, accepted)
+		request.Interval = time.Millisecond
+
+		// ACT.
+		err := rapi.Poll(http.DefaultClient, request, operationDone, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.Join(startErr, err) == nil && got == Response{Id: "1"}, "\n\n"+
+			"UT Name:  The status is located by the Location header when there's no Operation-Location header.\n"+
+			"\033[32mExpected: <nil> {Id:1}\033[0m\n"+
+			"\033[31mActual:   %v %+v\033[0m\n\n", errors.Join(startErr, err), got)
+	})
+
+	t.Run("When the operation fails.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newOperationServer(1, "failed", &count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+
+		request := rapi.PollRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL + "/jobs", OkStatusCode: http.StatusOK},
+			Location:    "/operations/1/status",
+			Interval:    time.Millisecond,
+		}
+
+		// ACT.
+		err := rapi.Poll(http.DefaultClient, &request, operationDone, &got)
+
+		// ASSERT.
+		assert.Truef(t, err != nil && err.Error() == "operation failed" && got == Response{}, "\n\n"+
+			"UT Name:  The 'error' of the terminal state is returned when the operation fails.\n"+
+			"\033[32mExpected: operation failed\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the context is canceled.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newOperationServer(math.MaxInt32, "succeeded", &count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+		defer cancel()
+
+		request := rapi.PollRequestMsg{
+			BaseRequest: rapi.BaseRequest{Context: ctx, Endpoint: srvFake.URL + "/jobs", OkStatusCode: http.StatusOK},
+			Location:    "/operations/1/status",
+			Interval:    10 * time.Millisecond,
+		}
+
+		// ACT.
+		err := rapi.Poll(http.DefaultClient, &request, operationDone, nil)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, context.DeadlineExceeded), "\n\n"+
+			"UT Name:  The 'error' of the context is returned when it's canceled.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", context.DeadlineExceeded, err)
+	})
+
+	t.Run("When the operation doesn't have a location.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		request := rapi.PollRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: "http://localhost/jobs"}}
+
+		// ACT.
+		err := rapi.Poll(http.DefaultClient, &request, operationDone, nil)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, rapi.ErrMissingLocation), "\n\n"+
+			"UT Name:  'rapi.ErrMissingLocation' is returned when the operation doesn't have a location.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrMissingLocation, err)
+	})
+}
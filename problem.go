@@ -0,0 +1,189 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+// Problem describes the problem details of a failed HTTP request (see RFC 9457).
+// It's returned when the response of a request doesn't have the OK status code, but has problem details as body.
+type Problem struct {
+	Type       string         // A URI reference which identifies the problem type. Defaults to "about:blank".
+	Title      string         // A short summary of the problem type.
+	Status     int            // The HTTP status code. Defaults to the status code of the response.
+	Detail     string         // An explanation specific to this occurrence of the problem.
+	Instance   string         // A URI reference which identifies this occurrence of the problem.
+	Extensions map[string]any // The members of the problem details which aren't defined by RFC 9457.
+
+	err error // The error of the registered problem type, if any.
+}
+
+// The registered problem types, by type URI.
+var (
+	problemTypesLock sync.RWMutex
+	problemTypes     = map[string]func() error{}
+)
+
+// RegisterProblemType registers newError for the problem type identified by typeURI.
+// When a Problem of that type is returned, the problem details are also decoded into the error returned by newError,
+// which must be a pointer, so it can be retrieved from the Problem using errors.As.
+// Registering a type URI again replaces the error.
+func RegisterProblemType(typeURI string, newError func() error) {
+	problemTypesLock.Lock()
+	defer problemTypesLock.Unlock()
+
+	problemTypes[typeURI] = newError
+}
+
+// Returns the registered error for the problem type identified by typeURI.
+func lookupProblemType(typeURI string) (func() error, bool) {
+	problemTypesLock.RLock()
+	defer problemTypesLock.RUnlock()
+
+	newError, found := problemTypes[typeURI]
+
+	return newError, found
+}
+
+// Error returns the description of p.
+func (p *Problem) Error() string {
+	description := fmt.Sprintf("status code %d", p.Status)
+
+	if p.Title != "" {
+		description += ": " + p.Title
+	}
+
+	if p.Detail != "" {
+		description += ": " + p.Detail
+	}
+
+	return description
+}
+
+// Unwrap returns the error of the registered problem type of p, or <nil> when its type isn't registered.
+func (p *Problem) Unwrap() error {
+	return p.err
+}
+
+// UnmarshalJSON decodes the problem details in data into p.
+// Members with an unexpected JSON type are ignored, as required by RFC 9457.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+
+	for name, value := range members {
+		switch name {
+		case "type":
+			decodeMember(value, &p.Type)
+		case "title":
+			decodeMember(value, &p.Title)
+		case "status":
+			decodeMember(value, &p.Status)
+		case "detail":
+			decodeMember(value, &p.Detail)
+		case "instance":
+			decodeMember(value, &p.Instance)
+		default:
+			var extension any
+
+			if decodeMember(value, &extension) {
+				if p.Extensions == nil {
+					p.Extensions = make(map[string]any)
+				}
+
+				p.Extensions[name] = extension
+			}
+		}
+	}
+
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+
+	return nil
+}
+
+// Decodes value into target, which is left untouched when value can't be decoded.
+// It reports whether value is decoded.
+func decodeMember[T any](value json.RawMessage, target *T) bool {
+	var decoded T
+
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return false
+	}
+
+	*target = decoded
+
+	return true
+}
+
+// Reports whether the body of response contains problem details.
+func isProblem(response *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+
+	return err == nil && mediaType == "application/problem+json"
+}
+
+// Returns the problem details in the body of response.
+// It returns an error if the body can't be read or doesn't contain problem details.
+func readProblem(response *http.Response) (*Problem, error) {
+	data, err := io.ReadAll(response.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var problem Problem
+
+	if err := json.Unmarshal(data, &problem); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal problem details: %w", err)
+	}
+
+	if problem.Status == 0 {
+		problem.Status = response.StatusCode
+	}
+
+	if newError, found := lookupProblemType(problem.Type); found {
+		problem.err = newError()
+
+		if err := json.Unmarshal(data, problem.err); err != nil {
+			problem.err = nil
+		}
+	}
+
+	return &problem, nil
+}
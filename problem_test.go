@@ -0,0 +1,176 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// A custom problem type.
+type outOfCreditError struct {
+	Balance int `json:"balance"`
+}
+
+// Error returns the description of err.
+func (err *outOfCreditError) Error() string {
+	return "out of credit"
+}
+
+// Register the custom problem type.
+func init() {
+	rapi.RegisterProblemType("https://example.com/probs/out-of-credit", func() error {
+		return &outOfCreditError{}
+	})
+}
+
+// UT: Decode the problem details of failed HTTP requests.
+func TestProblem(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the response contains problem details.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"title":"Not Found","detail":"No such user.","instance":"/users/1","trace":"abc","status":"invalid"}`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var problem *rapi.Problem
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &problem), "\n\n"+
+			"UT Name:  A 'rapi.Problem' is returned when the response contains problem details.\n"+
+			"\033[32mExpected: *rapi.Problem\033[0m\n"+
+			"\033[31mActual:   %T\033[0m\n\n", err)
+
+		assert.Equalf(t, problem.Type, "about:blank", "\n\n"+
+			"UT Name:  The type of the 'rapi.Problem' defaults to 'about:blank'.\n"+
+			"\033[32mExpected: about:blank\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", problem.Type)
+
+		assert.Equalf(t, problem.Status, http.StatusNotFound, "\n\n"+
+			"UT Name:  The status of the 'rapi.Problem' defaults to the status code of the response.\n"+
+			"\033[32mExpected: %d\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", http.StatusNotFound, problem.Status)
+
+		assert.Equalf(t, problem.Detail, "No such user.", "\n\n"+
+			"UT Name:  The detail of the 'rapi.Problem' is decoded.\n"+
+			"\033[32mExpected: No such user.\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", problem.Detail)
+
+		assert.Equalf(t, problem.Extensions["trace"], any("abc"), "\n\n"+
+			"UT Name:  The extension members of the 'rapi.Problem' are decoded.\n"+
+			"\033[32mExpected: abc\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", problem.Extensions["trace"])
+
+		assert.Equalf(t, problem.Error(), "status code 404: Not Found: No such user.", "\n\n"+
+			"UT Name:  The 'rapi.Problem' is described by its status, title and detail.\n"+
+			"\033[32mExpected: status code 404: Not Found: No such user.\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", problem.Error())
+	})
+
+	t.Run("When the response contains problem details of a registered type.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"type":"https://example.com/probs/out-of-credit","title":"Out of credit","balance":30}`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var outOfCredit *outOfCreditError
+		var problem *rapi.Problem
+
+		request := rapi.POSTRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &problem), "\n\n"+
+			"UT Name:  A 'rapi.Problem' is returned when the problem type is registered.\n"+
+			"\033[32mExpected: *rapi.Problem\033[0m\n"+
+			"\033[31mActual:   %T\033[0m\n\n", err)
+
+		assert.Truef(t, errors.As(err, &outOfCredit), "\n\n"+
+			"UT Name:  The error of the registered problem type is returned.\n"+
+			"\033[32mExpected: *rapi_test.outOfCreditError\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, outOfCredit.Balance, 30, "\n\n"+
+			"UT Name:  The problem details are decoded into the error of the registered problem type.\n"+
+			"\033[32mExpected: 30\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", outOfCredit.Balance)
+	})
+
+	t.Run("When the response doesn't contain problem details.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`<html></html>`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var problem *rapi.Problem
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Falsef(t, errors.As(err, &problem), "\n\n"+
+			"UT Name:  NO 'rapi.Problem' is returned when the body isn't valid problem details.\n"+
+			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
@@ -0,0 +1,114 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// Progress describes the progress of a transfer.
+type Progress struct {
+	Transferred int64         // The number of bytes transferred so far.
+	Total       int64         // The total number of bytes, or -1 when it's unknown.
+	Rate        float64       // The average transfer rate, in bytes per second.
+	ETA         time.Duration // The estimated remaining time, or -1 when it's unknown.
+	Done        bool          // Whether the transfer is complete.
+}
+
+// ProgressReporter reports the progress of the transfers of HTTP requests.
+// Each transfer keeps track of its own progress, so a reporter can be shared by concurrent requests, in which case its
+// callbacks are invoked concurrently.
+type ProgressReporter struct {
+	OnUpload   func(progress Progress) // Invoked with the progress of sending the payload of the request.
+	OnDownload func(progress Progress) // Invoked with the progress of receiving the body of the response.
+	Interval   time.Duration           // The minimum interval between two reports. The final report is always made.
+}
+
+// Returns r, wrapped so that reading it reports its progress to report. When report is <nil>, r is returned.
+func (reporter *ProgressReporter) wrap(r io.ReadCloser, total int64, report func(progress Progress)) io.ReadCloser {
+	if report == nil || r == nil || r == http.NoBody {
+		return r
+	}
+
+	if total <= 0 {
+		total = -1
+	}
+
+	return &progressReader{ReadCloser: r, report: report, interval: reporter.Interval, total: total, start: time.Now()}
+}
+
+// A reader which reports the progress of reading the reader it wraps.
+type progressReader struct {
+	io.ReadCloser                         // The wrapped reader.
+	report        func(progress Progress) // Invoked with the progress.
+	interval      time.Duration           // The minimum interval between two reports.
+	total         int64                   // The total number of bytes, or -1 when it's unknown.
+	transferred   int64                   // The number of bytes read so far.
+	start         time.Time               // The time the transfer started.
+	reported      time.Time               // The time of the last report.
+	done          bool                    // Whether the final report was made.
+}
+
+// Read reads from the wrapped reader into p and reports the progress.
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.transferred += int64(n)
+
+	if r.done {
+		return n, err
+	}
+
+	now := time.Now()
+
+	if err == io.EOF || (r.total >= 0 && r.transferred >= r.total) {
+		r.done = true
+		r.report(r.progress(now))
+	} else if n > 0 && now.Sub(r.reported) >= r.interval {
+		r.reported = now
+		r.report(r.progress(now))
+	}
+
+	return n, err
+}
+
+// Returns the progress at now.
+func (r *progressReader) progress(now time.Time) Progress {
+	progress := Progress{Transferred: r.transferred, Total: r.total, ETA: -1, Done: r.done}
+
+	if elapsed := now.Sub(r.start).Seconds(); elapsed > 0 {
+		progress.Rate = float64(r.transferred) / elapsed
+	}
+
+	if r.done {
+		progress.ETA = 0
+	} else if r.total >= 0 && progress.Rate > 0 {
+		progress.ETA = time.Duration(float64(r.total-r.transferred) / progress.Rate * float64(time.Second))
+	}
+
+	return progress
+}
@@ -0,0 +1,190 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/go-essentials/tstsrv"
+)
+
+// A recorder of progress reports, safe for concurrent use.
+type progressRecorder struct {
+	lock    sync.Mutex      // Protect concurrent access to reports.
+	reports []rapi.Progress // The recorded reports.
+}
+
+// Records progress.
+func (r *progressRecorder) record(progress rapi.Progress) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.reports = append(r.reports, progress)
+}
+
+// Returns the last recorded report.
+func (r *progressRecorder) last() rapi.Progress {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.reports) == 0 {
+		return rapi.Progress{}
+	}
+
+	return r.reports[len(r.reports)-1]
+}
+
+// UT: Report the progress of HTTP requests.
+func TestProgressReporter(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When a payload is uploaded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+		var uploads progressRecorder
+
+		payload := `{"data":"` + strings.Repeat("x", 64*1024) + `"}`
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+				Progress:     &rapi.ProgressReporter{OnUpload: uploads.record},
+			},
+			Payload: payload,
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, &got)
+		final := uploads.last()
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the progress is reported.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, final.Done && final.Transferred == int64(len(payload)) && final.Total == int64(len(payload)), "\n\n"+
+			"UT Name:  The final report contains the total number of uploaded bytes.\n"+
+			"\033[32mExpected: {Transferred:%d Total:%d Done:true}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", len(payload), len(payload), final)
+	})
+
+	t.Run("When a body is downloaded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		body := strings.Repeat("HELLO, WORLD! ", 4096)
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: body},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got string
+		var downloads progressRecorder
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL(),
+				OkStatusCode: http.StatusOK,
+				Progress:     &rapi.ProgressReporter{OnDownload: downloads.record},
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(http.DefaultClient, &got)
+		final := downloads.last()
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the progress is reported.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, final.Done && final.Transferred == int64(len(body)) && final.ETA == 0, "\n\n"+
+			"UT Name:  The final report contains the total number of downloaded bytes.\n"+
+			"\033[32mExpected: {Transferred:%d ETA:0 Done:true}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", len(body), final)
+	})
+
+	t.Run("When the reports are throttled.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: strings.Repeat("HELLO, WORLD! ", 65536)},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got string
+		var downloads progressRecorder
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL(),
+				OkStatusCode: http.StatusOK,
+				Progress:     &rapi.ProgressReporter{OnDownload: downloads.record, Interval: time.Hour},
+			},
+		}
+
+		// ACT.
+		request.GETPlain(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Equalf(t, len(downloads.reports), 2, "\n\n"+
+			"UT Name:  Only the first and the final report are made within the interval.\n"+
+			"\033[32mExpected: 2\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", len(downloads.reports))
+	})
+}
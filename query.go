@@ -0,0 +1,368 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SliceStyle defines how slices are encoded into query parameters.
+type SliceStyle int
+
+// The styles for encoding slices into query parameters.
+const (
+	SliceRepeat   SliceStyle = iota // Repeat the parameter for each value: "a=1&a=2".
+	SliceComma                      // Join the values with commas: "a=1,2".
+	SliceBrackets                   // Repeat the parameter, suffixed with brackets, for each value: "a[]=1&a[]=2".
+)
+
+// NestedStyle defines how the names of nested struct fields are encoded into query parameters.
+type NestedStyle int
+
+// The styles for encoding the names of nested struct fields into query parameters.
+const (
+	NestedBrackets NestedStyle = iota // Enclose the name of the nested field in brackets: "a[b]=1".
+	NestedDot                         // Join the names with a dot: "a.b=1".
+)
+
+// QueryMarshaler is implemented by types which encode themselves into query parameters.
+type QueryMarshaler interface {
+	MarshalQuery(name string, values url.Values) error // Adds the parameters for the value named name to values.
+}
+
+// QueryEncoder encodes structs into query parameters.
+//
+// Each exported field is encoded using the name in its `query` tag, or its own name when there's no tag. Fields tagged
+// with `query:"-"` are skipped, as are <nil> pointers. The name can be followed by these options:
+//   - omitempty: Skip the field when it has its zero value.
+//   - repeat, comma or brackets: Encode a slice using SliceRepeat, SliceComma or SliceBrackets.
+//   - unix or unixmilli: Encode a time.Time as the number of (milli)seconds since the Unix epoch.
+//
+// Times are encoded using the layout in the `layout` tag of their field, which defaults to time.RFC3339. Values which
+// implement QueryMarshaler encode themselves. Nested structs and maps are encoded using the NestedStyle.
+type QueryEncoder struct {
+	SliceStyle  SliceStyle  // The style for encoding slices, unless a field overrides it.
+	NestedStyle NestedStyle // The style for encoding the names of nested struct fields.
+}
+
+// EncodeQuery returns the query parameters of the struct v, encoded with the default QueryEncoder.
+// It returns an error if v can't be encoded.
+func EncodeQuery(v any) (url.Values, error) {
+	return QueryEncoder{}.Encode(v)
+}
+
+// Encode returns the query parameters of the struct v.
+// It returns an error if v can't be encoded.
+func (enc QueryEncoder) Encode(v any) (url.Values, error) {
+	values := make(url.Values)
+	value := reflect.ValueOf(v)
+
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("failed to encode query: %T isn't a struct", v)
+	}
+
+	// Copy a struct which is passed by value, so the QueryMarshaler methods of its fields with a pointer receiver are
+	// found as well.
+	if !value.CanAddr() {
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		value = copied
+	}
+
+	if err := enc.encodeStruct(values, "", value); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	return values, nil
+}
+
+// Adds the parameters for the fields of the struct value to values. The names of the fields are nested in prefix.
+// It returns an error if a field can't be encoded.
+func (enc QueryEncoder) encodeStruct(values url.Values, prefix string, value reflect.Value) error {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("query")
+		name, options, _ := strings.Cut(tag, ",")
+		fieldOptions := strings.Split(options, ",")
+
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+
+		if slices.Contains(fieldOptions, "omitempty") && fieldValue.IsZero() {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && indirectType(field.Type).Kind() == reflect.Struct {
+			for fieldValue.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					break
+				}
+
+				fieldValue = fieldValue.Elem()
+			}
+
+			if fieldValue.Kind() == reflect.Struct {
+				if err := enc.encodeStruct(values, prefix, fieldValue); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if err := enc.encodeValue(values, enc.nest(prefix, name), fieldValue, field.Tag, fieldOptions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Adds the parameters for value, named name, to values. The tag and options of its field define how it's encoded.
+// It returns an error if value can't be encoded.
+func (enc QueryEncoder) encodeValue(values url.Values, name string, value reflect.Value, tag reflect.StructTag, options []string) error {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		if marshaler, ok := value.Interface().(QueryMarshaler); ok {
+			return marshaler.MarshalQuery(name, values)
+		}
+
+		value = value.Elem()
+	}
+
+	if marshaler, ok := value.Interface().(QueryMarshaler); ok {
+		return marshaler.MarshalQuery(name, values)
+	}
+
+	if value.CanAddr() {
+		if marshaler, ok := value.Addr().Interface().(QueryMarshaler); ok {
+			return marshaler.MarshalQuery(name, values)
+		}
+	}
+
+	if value.Type() == timeType {
+		values.Add(name, formatTime(value.Interface().(time.Time), tag, options))
+
+		return nil
+	}
+
+	if isTextValue(value) {
+		formatted, err := formatValue(value)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		values.Add(name, formatted)
+
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return enc.encodeStruct(values, name, value)
+
+	case reflect.Map:
+		keys := make([]string, 0, value.Len())
+		items := make(map[string]reflect.Value, value.Len())
+
+		for key, item := range value.Seq2() {
+			formatted, err := formatValue(key)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			keys = append(keys, formatted)
+			items[formatted] = item
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			if err := enc.encodeValue(values, enc.nest(name, key), items[key], tag, options); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		return enc.encodeSlice(values, name, value, tag, options)
+	}
+
+	formatted, err := formatValue(value)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	values.Add(name, formatted)
+
+	return nil
+}
+
+// Adds the parameters for the slice value, named name, to values. The tag and options of its field define how it's
+// encoded. It returns an error if value can't be encoded.
+func (enc QueryEncoder) encodeSlice(values url.Values, name string, value reflect.Value, tag reflect.StructTag, options []string) error {
+	style := enc.SliceStyle
+
+	switch {
+	case slices.Contains(options, "repeat"):
+		style = SliceRepeat
+
+	case slices.Contains(options, "comma"):
+		style = SliceComma
+
+	case slices.Contains(options, "brackets"):
+		style = SliceBrackets
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	if elem := indirectType(value.Type().Elem()); elem.Kind() == reflect.Struct && elem != timeType {
+		for i := range value.Len() {
+			if err := enc.encodeValue(values, fmt.Sprintf("%s[%d]", name, i), value.Index(i), tag, options); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	items := make(url.Values)
+
+	for i := range value.Len() {
+		if err := enc.encodeValue(items, name, value.Index(i), tag, options); err != nil {
+			return err
+		}
+	}
+
+	switch style {
+	case SliceComma:
+		values.Add(name, strings.Join(items[name], ","))
+
+	case SliceBrackets:
+		values[name+"[]"] = append(values[name+"[]"], items[name]...)
+
+	default:
+		values[name] = append(values[name], items[name]...)
+	}
+
+	return nil
+}
+
+// Returns the name of the field name nested in prefix.
+func (enc QueryEncoder) nest(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+
+	case enc.NestedStyle == NestedDot:
+		return prefix + "." + name
+
+	default:
+		return prefix + "[" + name + "]"
+	}
+}
+
+// Returns the textual representation of t, as defined by the tag and options of its field.
+func formatTime(t time.Time, tag reflect.StructTag, options []string) string {
+	switch {
+	case slices.Contains(options, "unix"):
+		return strconv.FormatInt(t.Unix(), 10)
+
+	case slices.Contains(options, "unixmilli"):
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+
+	if layout := tag.Get("layout"); layout != "" {
+		return t.Format(layout)
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// Returns the type t points to, following all pointers.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// Returns endpoint with query appended to its query string.
+func appendQuery(endpoint string, query url.Values) string {
+	if len(query) == 0 {
+		return endpoint
+	}
+
+	endpoint, fragment, hasFragment := strings.Cut(endpoint, "#")
+
+	switch {
+	case !strings.Contains(endpoint, "?"):
+		endpoint += "?"
+
+	case !strings.HasSuffix(endpoint, "?") && !strings.HasSuffix(endpoint, "&"):
+		endpoint += "&"
+	}
+
+	endpoint += query.Encode()
+
+	if hasFragment {
+		endpoint += "#" + fragment
+	}
+
+	return endpoint
+}
@@ -0,0 +1,229 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// A range which encodes itself into query parameters.
+type queryRange struct {
+	From, To int // The bounds of the range.
+}
+
+// MarshalQuery adds the bounds of r, named name, to values.
+func (r queryRange) MarshalQuery(name string, values url.Values) error {
+	values.Add(name, strconv.Itoa(r.From)+".."+strconv.Itoa(r.To))
+
+	return nil
+}
+
+// A bounding box which encodes itself into query parameters, using a method with a pointer receiver.
+type queryBox struct {
+	A, B int // The corners of the box.
+}
+
+// MarshalQuery adds the corners of b, named name, to values.
+func (b *queryBox) MarshalQuery(name string, values url.Values) error {
+	values.Add(name, strconv.Itoa(b.A)+","+strconv.Itoa(b.B))
+
+	return nil
+}
+
+// UT: Encode a struct into query parameters.
+func TestQueryEncoder(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Paging struct {
+		Page int `query:"page"`
+		Size int `query:"size,omitempty"`
+	}
+
+	type Owner struct {
+		Login string `query:"login"`
+	}
+
+	type Filter struct {
+		Paging
+		Name    string     `query:"name,omitempty"`
+		Ids     []int      `query:"id"`
+		Tags    []string   `query:"tags,comma"`
+		States  []string   `query:"state,brackets"`
+		Owner   *Owner     `query:"owner"`
+		Since   time.Time  `query:"since" layout:"2006-01-02"`
+		Until   time.Time  `query:"until,unix"`
+		Range   queryRange `query:"range"`
+		Skipped string     `query:"-"`
+		Missing *string    `query:"missing"`
+	}
+
+	t.Run("When the struct is valid.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		filter := Filter{
+			Paging: Paging{Page: 2},
+			Ids:    []int{1, 2},
+			Tags:   []string{"a", "b"},
+			States: []string{"open"},
+			Owner:  &Owner{Login: "kdeconinck"},
+			Since:  time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			Until:  time.Unix(1700000000, 0),
+			Range:  queryRange{From: 1, To: 9},
+		}
+
+		want := "id=1&id=2&owner%5Blogin%5D=kdeconinck&page=2&range=1..9&since=2025-01-02&state%5B%5D=open&tags=a%2Cb" +
+			"&until=1700000000"
+
+		// ACT.
+		values, err := rapi.EncodeQuery(filter)
+		got := values.Encode()
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the struct is valid.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  The struct is encoded into query parameters.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the dot notation is used for nested structs.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		type Item struct {
+			Sku string `query:"sku"`
+		}
+
+		type Order struct {
+			Items []Item `query:"items"`
+		}
+
+		encoder := rapi.QueryEncoder{NestedStyle: rapi.NestedDot}
+		want := "items%5B0%5D.sku=a&items%5B1%5D.sku=b"
+
+		// ACT.
+		values, err := encoder.Encode(Order{Items: []Item{{Sku: "a"}, {Sku: "b"}}})
+		got := values.Encode()
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the struct is valid.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  The nested structs are encoded using the dot notation.\n"+
+			"\033[32mExpected: %s\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", want, got)
+	})
+
+	t.Run("When a field implements 'rapi.QueryMarshaler' with a pointer receiver.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		type Search struct {
+			Box queryBox `query:"bbox"`
+		}
+
+		want := "bbox=1%2C2"
+
+		// ACT.
+		values, err := rapi.EncodeQuery(Search{Box: queryBox{A: 1, B: 2}})
+		got := values.Encode()
+
+		// ASSERT.
+		assert.Truef(t, err == nil && got == want, "\n\n"+
+			"UT Name:  The field encodes itself, even when the struct is passed by value.\n"+
+			"\033[32mExpected: %s <nil>\033[0m\n"+
+			"\033[31mActual:   %s %v\033[0m\n\n", want, got, err)
+	})
+
+	t.Run("When a field has an unsupported type.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ACT.
+		_, err := rapi.EncodeQuery(struct {
+			Callback func() `query:"callback"`
+		}{Callback: func() {}})
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when a field has an unsupported type.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
+
+// UT: Make an HTTP request with query parameters.
+func TestQuery(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// FAKE SETUP.
+	var count atomic.Int32
+
+	srvFake := newEchoServer(&count)
+
+	defer srvFake.Close()
+
+	// ARRANGE.
+	var got echoedRequest
+
+	request := rapi.GETRequestMsg{
+		BaseRequest: rapi.BaseRequest{
+			Endpoint:     srvFake.URL + "/search?lang=en",
+			Query:        url.Values{"q": {"a&b"}},
+			OkStatusCode: http.StatusOK,
+		},
+	}
+
+	// ACT.
+	err := request.GET(http.DefaultClient, &got)
+
+	// ASSERT.
+	assert.Nilf(t, err, "\n\n"+
+		"UT Name:  NO 'error' is returned when the request has query parameters.\n"+
+		"\033[32mExpected: <nil>\033[0m\n"+
+		"\033[31mActual:   %v\033[0m\n\n", err)
+
+	assert.Equalf(t, got.URI, "/search?lang=en&q=a%26b", "\n\n"+
+		"UT Name:  The query parameters are merged with the query of the endpoint.\n"+
+		"\033[32mExpected: /search?lang=en&q=a%%26b\033[0m\n"+
+		"\033[31mActual:   %s\033[0m\n\n", got.URI)
+}
@@ -28,25 +28,45 @@ package rapi
 
 import (
 	"bytes"
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
 )
 
 // BaseRequest describes the "base" structure of an HTTP request.
 type BaseRequest struct {
+	Context                context.Context      // The context of the request, which cancels it. Defaults to context.Background().
 	Endpoint               string               // The URL to send the request to.
+	Query                  url.Values           // The query parameters to append to the query string of the endpoint.
 	HttpHeaders            map[string]string    // The HTTP headers to include in the request.
 	HttpStatusCodeHandlers map[int]func() error // Map containing the HTTP status codes and their corresponding handlers.
+	StatusRangeHandlers    []StatusRangeHandler // The handlers for ranges of HTTP status codes. The first matching one is used.
+	StatusClassHandlers    map[int]func() error // Map containing the classes of HTTP status codes (such as 4 for 4xx) and their handlers.
+	DefaultStatusHandler   func() error         // The handler for the responses without the OK status code which aren't handled otherwise.
+	IgnoreNotImplemented   bool                 // Whether responses with status code 501 are handled like any other status code.
 	OkStatusCode           int                  // The HTTP status code that indicates a successful request.
+	OkStatusCodes          []int                // Additional HTTP status codes that indicate a successful request.
+	Auth                   *TokenAuth           // Authenticates the request, and re-authenticates when it's rejected.
+	Progress               *ProgressReporter    // Reports the progress of sending the payload and receiving the body.
+	Bandwidth              *BandwidthLimits     // Limits the bandwidth of sending the payload and receiving the body.
+	Compression            *Compression         // Compresses the payload and decompresses the body.
+	ResponseOptions        *ResponseOptions     // Defines how the body is read and decoded, instead of the client's.
+	ErrorResult            any                  // Receives the body of responses without the OK status code.
+	StatusResults          map[int]any          // Receive the body of responses with a given status code, instead of the result.
+	Idempotency            *Idempotency         // Sends an idempotency key, so the request can be retried safely.
+	Coalesce               *Coalescer           // Coalesces the request with identical concurrent GET (and HEAD) requests.
 }
 
 // POSTRequestMsg describes an HTTP POST request.
 type POSTRequestMsg struct {
-	BaseRequest        // The "base" HTTP request.
-	Payload     string // The payload of the request.
+	BaseRequest            // The "base" HTTP request.
+	Payload     string     // The payload of the request.
+	Multipart   *Multipart // The multipart/form-data payload of the request. When set, it's used instead of Payload.
 }
 
 // GETRequestMsg describes an HTTP GET request.
@@ -54,127 +74,420 @@ type GETRequestMsg struct {
 	BaseRequest // The "base" HTTP request.
 }
 
+// HEADRequestMsg describes an HTTP HEAD request.
+type HEADRequestMsg struct {
+	BaseRequest // The "base" HTTP request.
+}
+
+// PUTRequestMsg describes an HTTP PUT request.
+type PUTRequestMsg struct {
+	BaseRequest        // The "base" HTTP request.
+	Payload     string // The payload of the request.
+}
+
+// PATCHRequestMsg describes an HTTP PATCH request.
+type PATCHRequestMsg struct {
+	BaseRequest           // The "base" HTTP request.
+	Payload     string    // The payload of the request.
+	JSONPatch   JSONPatch // The JSON Patch document of the request. When set, it's used instead of Payload.
+	MergePatch  any       // The JSON Merge Patch document of the request. When set, it's used instead of Payload.
+}
+
+// StatusRange returns the HTTP status codes from first to last (inclusive), such as StatusRange(200, 299) for all
+// the 2xx status codes. It's intended to be used as the OkStatusCodes of a BaseRequest.
+func StatusRange(first, last int) []int {
+	codes := make([]int, 0, max(last-first+1, 0))
+
+	for code := first; code <= last; code++ {
+		codes = append(codes, code)
+	}
+
+	return codes
+}
+
 // POST uses client to make an HTTP POST request described by req and updates result.
+// Fields of result tagged with `rapi:"header:<name>"` or `rapi:"status"` receive the headers and the status code, and
+// the ones tagged with `rapi:"idempotency-key"` or `rapi:"replayed"` the idempotency key and whether it's replayed.
+// An empty body leaves result untouched (see ResponseOptions.ZeroEmptyResult), and a <nil> result discards the body.
 // It return an error if any error occurs or <nil> when no error was returned.
 func (req *POSTRequestMsg) POST(client *http.Client, result any) error {
-	requestBytes := bytes.NewBuffer([]byte(req.Payload))
-	request, _ := http.NewRequest("POST", req.Endpoint, requestBytes)
+	body := textPayload(req.Payload, "")
 
-	for key, value := range req.HttpHeaders {
-		request.Header.Add(key, value)
+	if req.Multipart != nil {
+		body = req.Multipart.payload()
 	}
 
-	response, err := client.Do(request)
+	return req.exchange(client, http.MethodPost, body, result)
+}
+
+// GET uses client to make an HTTP GET request described by req and updates result.
+// Fields of result tagged with `rapi:"header:<name>"` or `rapi:"status"` receive the headers and the status code.
+// An empty body leaves result untouched (see ResponseOptions.ZeroEmptyResult), and a <nil> result discards the body.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *GETRequestMsg) GET(client *http.Client, result any) error {
+	return req.exchange(client, http.MethodGet, nil, result)
+}
+
+// GETPlain uses client to make an HTTP GET request described by req and updates result.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *GETRequestMsg) GETPlain(client *http.Client, result *string) error {
+	responseData, _, err := req.do(client, http.MethodGet, nil)
 
 	if err != nil {
-		return err
+		return req.wrapError(http.MethodGet, err)
 	}
 
-	defer response.Body.Close()
+	*result = string(responseData)
 
-	if handler, found := req.HttpStatusCodeHandlers[response.StatusCode]; found {
-		return handler()
-	}
+	return nil
+}
+
+// HEAD uses client to make an HTTP HEAD request described by req and updates result.
+// Only the fields of result tagged with `rapi:"header:<name>"` or `rapi:"status"` are updated.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *HEADRequestMsg) HEAD(client *http.Client, result any) error {
+	return req.exchange(client, http.MethodHead, nil, result)
+}
+
+// PUT uses client to make an HTTP PUT request described by req and updates result.
+// Fields of result tagged with `rapi:"header:<name>"` or `rapi:"status"` receive the headers and the status code.
+// An empty body leaves result untouched (see ResponseOptions.ZeroEmptyResult), and a <nil> result discards the body.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *PUTRequestMsg) PUT(client *http.Client, result any) error {
+	return req.exchange(client, http.MethodPut, textPayload(req.Payload, ""), result)
+}
 
-	if response.StatusCode == http.StatusNotImplemented {
-		return errors.New("not implemented")
+// PATCH uses client to make an HTTP PATCH request described by req and updates result.
+// Fields of result tagged with `rapi:"header:<name>"` or `rapi:"status"` receive the headers and the status code, and
+// the ones tagged with `rapi:"idempotency-key"` or `rapi:"replayed"` the idempotency key and whether it's replayed.
+// An empty body leaves result untouched (see ResponseOptions.ZeroEmptyResult), and a <nil> result discards the body.
+// It return an error if any error occurs or <nil> when no error was returned.
+func (req *PATCHRequestMsg) PATCH(client *http.Client, result any) error {
+	body, err := req.payload()
+
+	if err != nil {
+		return req.wrapError(http.MethodPatch, err)
 	}
 
-	if response.StatusCode != req.OkStatusCode {
-		return fmt.Errorf("status code %d", response.StatusCode)
+	return req.exchange(client, http.MethodPatch, body, result)
+}
+
+// Uses client to make an HTTP request with method described by req, which sends body, and updates result.
+// It returns an error if the request fails, if the response doesn't have the OK status code or if it can't be decoded.
+func (req *BaseRequest) exchange(client *http.Client, method string, body *payload, result any) error {
+	base, err := req.idempotent()
+
+	if err != nil {
+		return req.wrapError(method, err)
 	}
 
-	responseData, err := io.ReadAll(response.Body)
+	responseData, response, err := base.do(client, method, body)
 
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return req.wrapError(method, err)
 	}
 
-	if err := json.Unmarshal(responseData, &result); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	return req.wrapError(method, base.decode(client, response, responseData, result))
+}
+
+// Decodes responseData, the body of response, into result (or the target declared in StatusResults) and binds the
+// headers and the status code of response. Empty bodies and the bodies of HEAD responses aren't decoded, and a <nil> result discards the body.
+// It returns a DecodeError if the body isn't JSON or can't be decoded, or an error if the headers can't be decoded.
+func (req *BaseRequest) decode(client *http.Client, response *http.Response, responseData []byte, result any) error {
+	if result = req.target(response.StatusCode, result); result == nil {
+		return nil
 	}
 
-	return nil
+	opts := req.options(client)
+
+	if response.Request.Method != http.MethodHead && len(bytes.TrimSpace(responseData)) > 0 {
+		if (opts == nil || !opts.IgnoreContentType) && !isJSONContentType(response) {
+			return newDecodeError(response, responseData, ErrUnexpectedContentType)
+		}
+
+		if err := decodeJSON(responseData, result, opts); err != nil {
+			return newDecodeError(response, responseData, err)
+		}
+	} else if opts != nil && opts.ZeroEmptyResult {
+		if value := reflect.ValueOf(result); value.Kind() == reflect.Pointer && !value.IsNil() {
+			value.Elem().SetZero()
+		}
+	}
+
+	return bindResponse(response, result, req.Idempotency)
 }
 
-// GET uses client to make an HTTP GET request described by req and updates result.
-// It return an error if any error occurs or <nil> when no error was returned.
-func (req *GETRequestMsg) GET(client *http.Client, result any) error {
-	request, _ := http.NewRequest("GET", req.Endpoint, nil)
+// Returns the context of req.
+func (req *BaseRequest) context() context.Context {
+	if req.Context == nil {
+		return context.Background()
+	}
 
-	for key, value := range req.HttpHeaders {
-		request.Header.Add(key, value)
+	return req.Context
+}
+
+// Reports whether statusCode indicates a successful request.
+func (req *BaseRequest) isOk(statusCode int) bool {
+	return statusCode == req.OkStatusCode || slices.Contains(req.OkStatusCodes, statusCode)
+}
+
+// The payload of an HTTP request.
+type payload struct {
+	open        func() (io.Reader, error) // Returns the payload. Invoked for every attempt, so the request can be replayed.
+	contentType string                    // The content type of the payload, unless it's set in the headers.
+}
+
+// Returns the payload consisting of text, with contentType.
+func textPayload(text, contentType string) *payload {
+	return &payload{
+		open: func() (io.Reader, error) {
+			return strings.NewReader(text), nil
+		},
+		contentType: contentType,
 	}
+}
 
-	response, err := client.Do(request)
+// Closes r when it's an io.Closer. Payloads can be pipes which are written by a goroutine, which only stops once the
+// pipe is closed, so a payload which is opened must be closed even when it isn't sent.
+func closeReader(r io.Reader) {
+	if closer, ok := r.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// Uses client to make an HTTP request with method described by req and returns the body of the response, and the
+// response itself (of which the body is already closed). Identical GET and HEAD requests are coalesced when req has a
+// Coalescer, in which case the body and the headers of the response are shared and must not be modified, but its
+// status code is checked by every request.
+// It returns an error if the request fails or if the response doesn't have the expected status code.
+func (req *BaseRequest) do(client *http.Client, method string, body *payload) ([]byte, *http.Response, error) {
+	if req.Coalesce == nil || (method != http.MethodGet && method != http.MethodHead) {
+		return req.transfer(client, method, body)
+	}
+
+	responseData, shared, err := req.Coalesce.do(req.context(), req.Coalesce.key(client, method, req), func(ctx context.Context) ([]byte, *http.Response, error) {
+		sharedReq := *req
+		sharedReq.Context = ctx
+
+		response, err := sharedReq.retrieve(client, method, body)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		defer response.Body.Close()
+
+		responseData, err := io.ReadAll(response.Body)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		return responseData, response, nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := *shared
+	response.Body = io.NopCloser(bytes.NewReader(responseData))
+
+	if err := req.check(client, &response, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return responseData, &response, nil
+}
+
+// Uses client to make an HTTP request with method described by req and returns the body of the response, and the
+// response itself (of which the body is already closed).
+// It returns an error if the request fails or if the response doesn't have the expected status code.
+func (req *BaseRequest) transfer(client *http.Client, method string, body *payload) ([]byte, *http.Response, error) {
+	response, err := req.receive(client, method, body, nil)
 
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	defer response.Body.Close()
 
-	if handler, found := req.HttpStatusCodeHandlers[response.StatusCode]; found {
-		return handler()
+	responseData, err := io.ReadAll(response.Body)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if response.StatusCode == http.StatusNotImplemented {
-		return errors.New("not implemented")
+	return responseData, response, nil
+}
+
+// Uses client to make an HTTP request with method described by req and returns the response. The caller must close
+// its body. The response is accepted when it has the OK status code, or when accept reports true for its status code.
+// It returns an error if the request fails or if the response isn't accepted (see StatusError and Problem).
+func (req *BaseRequest) receive(client *http.Client, method string, body *payload, accept func(statusCode int) bool) (*http.Response, error) {
+	response, err := req.retrieve(client, method, body)
+
+	if err != nil {
+		return nil, err
 	}
 
-	if response.StatusCode != req.OkStatusCode {
-		return fmt.Errorf("status code %d", response.StatusCode)
+	if err := req.check(client, response, accept); err != nil {
+		return nil, err
 	}
 
-	responseData, err := io.ReadAll(response.Body)
+	return response, nil
+}
+
+// Uses client to make an HTTP request with method described by req and returns the response, of which the body is
+// throttled, reported, decompressed and limited as described by req. The caller must close its body.
+// It returns an error if the request fails, or if the body can't be decompressed or exceeds its limit.
+func (req *BaseRequest) retrieve(client *http.Client, method string, body *payload) (*http.Response, error) {
+	response, err := req.send(client, method, body)
 
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
+	}
+
+	if req.Bandwidth != nil {
+		response.Body = limitBody(response.Body, req.Bandwidth.Download)
+	}
+
+	if req.Progress != nil {
+		response.Body = req.Progress.wrap(response.Body, response.ContentLength, req.Progress.OnDownload)
+	}
+
+	var maxDecompressedSize int64
+
+	if req.Compression != nil {
+		maxDecompressedSize = req.Compression.MaxDecompressedSize
 	}
 
-	if err := json.Unmarshal(responseData, &result); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	if err := decompressBody(response, maxDecompressedSize); err != nil {
+		return nil, err
+	}
+
+	if req.ResponseOptions != nil {
+		if err := limitResponseBody(response, req.ResponseOptions.MaxBodySize); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// Uses client to check the status code of response, which is accepted when it's the OK status code of req, or when
+// accept reports true for it. The body of response is closed unless it's accepted.
+// It returns an error if the request fails or if the response isn't accepted (see StatusError and Problem).
+func (req *BaseRequest) check(client *http.Client, response *http.Response, accept func(statusCode int) bool) error {
+	accepted := req.isOk(response.StatusCode) || (accept != nil && accept(response.StatusCode))
+
+	if handler, found := req.handler(response.StatusCode, accepted); found {
+		response.Body.Close()
+
+		return handler()
+	}
+
+	if response.StatusCode == http.StatusNotImplemented && !req.IgnoreNotImplemented {
+		response.Body.Close()
+
+		return ErrNotImplemented
+	}
+
+	if !accepted {
+		defer response.Body.Close()
+
+		return req.statusError(client, response)
 	}
 
 	return nil
 }
 
-// GETPlain uses client to make an HTTP GET request described by req and updates result.
-// It return an error if any error occurs or <nil> when no error was returned.
-func (req *GETRequestMsg) GETPlain(client *http.Client, result *string) error {
-	request, _ := http.NewRequest("GET", req.Endpoint, nil)
+// Uses client to send an HTTP request with method described by req and returns the response.
+// When the request is rejected because of its credentials, they are refreshed and the request is replayed once.
+// It returns an error if the request can't be sent, or if the credentials can't be refreshed.
+func (req *BaseRequest) send(client *http.Client, method string, body *payload) (*http.Response, error) {
+	response, generation, err := req.sendOnce(client, method, body)
 
-	for key, value := range req.HttpHeaders {
-		request.Header.Add(key, value)
+	if err != nil || req.Auth == nil || !req.Auth.rejected(response) {
+		return response, err
 	}
 
-	response, err := client.Do(request)
+	io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+
+	if err := req.Auth.refresh(generation); err != nil {
+		return nil, err
+	}
+
+	response, _, err = req.sendOnce(client, method, body)
+
+	return response, err
+}
+
+// Uses client to send an HTTP request with method described by req and returns the response.
+// It also returns the generation of the credentials that were used to authenticate the request.
+func (req *BaseRequest) sendOnce(client *http.Client, method string, body *payload) (*http.Response, int, error) {
+	var requestBody io.Reader
+
+	if body != nil {
+		var err error
+
+		if requestBody, err = body.open(); err != nil {
+			return nil, 0, err
+		}
+
+		if req.Compression != nil && req.Compression.Encoding != "" {
+			if requestBody, err = compressBody(requestBody, req.Compression.Encoding); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	request, err := http.NewRequestWithContext(req.context(), method, appendQuery(req.Endpoint, req.Query), requestBody)
 
 	if err != nil {
-		return err
+		closeReader(requestBody)
+
+		return nil, 0, err
 	}
 
-	defer response.Body.Close()
+	for key, value := range req.HttpHeaders {
+		request.Header.Add(key, value)
+	}
 
-	if handler, found := req.HttpStatusCodeHandlers[response.StatusCode]; found {
-		return handler()
+	if req.Bandwidth != nil {
+		request.Body = limitBody(request.Body, req.Bandwidth.Upload)
 	}
 
-	if response.StatusCode == http.StatusNotImplemented {
-		return errors.New("not implemented")
+	if req.Progress != nil {
+		request.Body = req.Progress.wrap(request.Body, request.ContentLength, req.Progress.OnUpload)
 	}
 
-	if response.StatusCode != req.OkStatusCode {
-		return fmt.Errorf("status code %d", response.StatusCode)
+	if body != nil && body.contentType != "" && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", body.contentType)
 	}
 
-	responseData, err := io.ReadAll(response.Body)
+	if req.Compression != nil {
+		if body != nil && req.Compression.Encoding != "" {
+			request.Header.Set("Content-Encoding", req.Compression.Encoding)
+		}
 
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		if request.Header.Get("Accept-Encoding") == "" {
+			request.Header.Set("Accept-Encoding", req.Compression.acceptEncoding())
+		}
 	}
 
-	*result = string(responseData)
+	var generation int
 
-	return nil
+	if req.Auth != nil {
+		if generation, err = req.Auth.apply(request); err != nil {
+			closeReader(request.Body)
+
+			return nil, 0, err
+		}
+	}
+
+	response, err := client.Do(request)
+
+	return response, generation, err
 }
@@ -29,6 +29,8 @@ package rapi_test
 import (
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-essentials/assert"
@@ -105,9 +107,9 @@ func TestPOST(t *testing.T) {
 			"\033[32mExpected: NOT <nil>\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err)
 
-		assert.Equalf(t, err.Error(), "error raised from the custom handler", "\n\n"+
+		assert.Equalf(t, err.Error(), "POST "+srvFake.URL()+": error raised from the custom handler", "\n\n"+
 			"UT Name:  The custom handler is for the received status code is invoked.\n"+
-			"\033[32mExpected: error raised from the custom handler\033[0m\n"+
+			"\033[32mExpected: POST <URL>: error raised from the custom handler\033[0m\n"+
 			"\033[31mActual:   %s\033[0m\n\n", err.Error())
 	})
 
@@ -177,9 +179,9 @@ func TestPOST(t *testing.T) {
 			"\033[32mExpected: NOT <nil>\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err)
 
-		assert.Equalf(t, err.Error(), "status code 400", "\n\n"+
+		assert.Equalf(t, err.Error(), "POST "+srvFake.URL()+": status code 400", "\n\n"+
 			"UT Name:  An 'error' is returned when the response is different from the 'OK' status code.\n"+
-			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[32mExpected: POST <URL>: status code 400\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err.Error())
 	})
 
@@ -303,6 +305,38 @@ func TestPOST(t *testing.T) {
 			"\033[32mExpected: %v\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", got, want)
 	})
+
+	t.Run("When the result is <nil>.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: "not json"},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL(),
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.POST(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the body is discarded.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
 }
 
 // UT: Make an HTTP GET request.
@@ -374,9 +408,9 @@ func TestGET(t *testing.T) {
 			"\033[32mExpected: NOT <nil>\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err)
 
-		assert.Equalf(t, err.Error(), "error raised from the custom handler", "\n\n"+
+		assert.Equalf(t, err.Error(), "GET "+srvFake.URL()+": error raised from the custom handler", "\n\n"+
 			"UT Name:  The custom handler is for the received status code is invoked.\n"+
-			"\033[32mExpected: error raised from the custom handler\033[0m\n"+
+			"\033[32mExpected: GET <URL>: error raised from the custom handler\033[0m\n"+
 			"\033[31mActual:   %s\033[0m\n\n", err.Error())
 	})
 
@@ -446,9 +480,9 @@ func TestGET(t *testing.T) {
 			"\033[32mExpected: NOT <nil>\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err)
 
-		assert.Equalf(t, err.Error(), "status code 400", "\n\n"+
+		assert.Equalf(t, err.Error(), "GET "+srvFake.URL()+": status code 400", "\n\n"+
 			"UT Name:  An 'error' is returned when the response is different from the 'OK' status code.\n"+
-			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[32mExpected: GET <URL>: status code 400\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err.Error())
 	})
 
@@ -572,6 +606,94 @@ func TestGET(t *testing.T) {
 			"\033[32mExpected: %v\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", got, want)
 	})
+
+	t.Run("When the HTTP response has no content.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusNoContent},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		type Response struct {
+			Id string `json:"Id"`
+		}
+
+		var got Response = Response{Id: "0"}
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:      srvFake.URL(),
+				OkStatusCodes: rapi.StatusRange(200, 299),
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the HTTP response has no content.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Id, "0", "\n\n"+
+			"UT Name:  The result is left untouched when the HTTP response has no content.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Id)
+	})
+
+	t.Run("When the HTTP response has an empty body which zeroes the result.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusAccepted},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		type Response struct {
+			Id string `json:"Id"`
+		}
+
+		var got Response = Response{Id: "0"}
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:        srvFake.URL(),
+				OkStatusCode:    http.StatusOK,
+				OkStatusCodes:   []int{http.StatusAccepted},
+				ResponseOptions: &rapi.ResponseOptions{ZeroEmptyResult: true},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the HTTP response has an empty body.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.Id, "", "\n\n"+
+			"UT Name:  The result is reset to its zero value when the HTTP response has an empty body.\n"+
+			"\033[32mExpected: \033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Id)
+	})
 }
 
 // UT: Make a "plain" HTTP GET request.
@@ -640,9 +762,9 @@ func TestGETPlain(t *testing.T) {
 			"\033[32mExpected: NOT <nil>\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err)
 
-		assert.Equalf(t, err.Error(), "error raised from the custom handler", "\n\n"+
+		assert.Equalf(t, err.Error(), "GET "+srvFake.URL()+": error raised from the custom handler", "\n\n"+
 			"UT Name:  The custom handler is for the received status code is invoked.\n"+
-			"\033[32mExpected: error raised from the custom handler\033[0m\n"+
+			"\033[32mExpected: GET <URL>: error raised from the custom handler\033[0m\n"+
 			"\033[31mActual:   %s\033[0m\n\n", err.Error())
 	})
 
@@ -712,9 +834,9 @@ func TestGETPlain(t *testing.T) {
 			"\033[32mExpected: NOT <nil>\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err)
 
-		assert.Equalf(t, err.Error(), "status code 400", "\n\n"+
+		assert.Equalf(t, err.Error(), "GET "+srvFake.URL()+": status code 400", "\n\n"+
 			"UT Name:  An 'error' is returned when the response is different from the 'OK' status code.\n"+
-			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[32mExpected: GET <URL>: status code 400\033[0m\n"+
 			"\033[31mActual:   %v\033[0m\n\n", err.Error())
 	})
 
@@ -797,3 +919,126 @@ func TestGETPlain(t *testing.T) {
 			"\033[31mActual:   %s\033[0m\n\n", got)
 	})
 }
+
+// UT: Make an HTTP HEAD request.
+func TestHEAD(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the HTTP response has headers.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Total-Count", "42")
+			w.Write([]byte(`{"id":"0"}`))
+		}))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		type Response struct {
+			Id         string `json:"id"`
+			Status     int    `json:"-" rapi:"status"`
+			TotalCount int    `json:"-" rapi:"header:X-Total-Count"`
+		}
+
+		var got Response
+
+		request := rapi.HEADRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.HEAD(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the HTTP response has headers.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got, Response{Status: http.StatusOK, TotalCount: 42}, "\n\n"+
+			"UT Name:  The headers and the status code are bound into the result.\n"+
+			"\033[32mExpected: {Status:200 TotalCount:42}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", got)
+	})
+}
+
+// UT: Make an HTTP PUT request.
+func TestPUT(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the HTTP response is valid JSON.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		request := rapi.PUTRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK},
+			Payload:     `{"id":"0"}`,
+		}
+
+		// ACT.
+		err := request.PUT(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the HTTP response is valid JSON.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, got.Method == http.MethodPut && got.Body == `{"id":"0"}`, "\n\n"+
+			"UT Name:  The payload is sent using an HTTP PUT request.\n"+
+			"\033[32mExpected: PUT {\"id\":\"0\"}\033[0m\n"+
+			"\033[31mActual:   %s %s\033[0m\n\n", got.Method, got.Body)
+	})
+}
+
+// UT: Make an HTTP PATCH request.
+func TestPATCH(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the HTTP response is valid JSON.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		request := rapi.PATCHRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK},
+			Payload:     `{"id":"0"}`,
+		}
+
+		// ACT.
+		err := request.PATCH(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the HTTP response is valid JSON.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, got.Method == http.MethodPatch && got.Body == `{"id":"0"}`, "\n\n"+
+			"UT Name:  The payload is sent using an HTTP PATCH request.\n"+
+			"\033[32mExpected: PATCH {\"id\":\"0\"}\033[0m\n"+
+			"\033[31mActual:   %s %s\033[0m\n\n", got.Method, got.Body)
+	})
+}
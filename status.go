@@ -0,0 +1,136 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNotImplemented is returned when the response of an HTTP request has status code 501 (Not Implemented), unless
+// it's handled by a handler or BaseRequest.IgnoreNotImplemented is set.
+var ErrNotImplemented = errors.New("not implemented")
+
+// StatusRangeHandler describes the handler for the responses with a status code from First to Last (inclusive).
+type StatusRangeHandler struct {
+	First   int          // The first HTTP status code of the range.
+	Last    int          // The last HTTP status code of the range.
+	Handler func() error // The handler for the responses with a status code in the range.
+}
+
+// Returns the handler for a response with statusCode, which is accepted when accepted is true.
+// Handlers for the exact status code take precedence over the ones for a range, then the ones for the class of
+// status codes. The default handler is only used for responses which aren't accepted.
+func (req *BaseRequest) handler(statusCode int, accepted bool) (func() error, bool) {
+	if handler, found := req.HttpStatusCodeHandlers[statusCode]; found {
+		return handler, true
+	}
+
+	for _, rangeHandler := range req.StatusRangeHandlers {
+		if statusCode >= rangeHandler.First && statusCode <= rangeHandler.Last {
+			return rangeHandler.Handler, true
+		}
+	}
+
+	if handler, found := req.StatusClassHandlers[statusCode/100]; found {
+		return handler, true
+	}
+
+	if !accepted && req.DefaultStatusHandler != nil {
+		return req.DefaultStatusHandler, true
+	}
+
+	return nil, false
+}
+
+// StatusError is returned when the response of an HTTP request doesn't have the OK status code.
+type StatusError struct {
+	StatusCode int   // The HTTP status code of the response.
+	Result     any   // The decoded body of the response, when the request declares a target for it.
+	Err        error // The reason why the body can't be decoded into the declared target, such as a *DecodeError.
+}
+
+// Error returns the description of err.
+func (err *StatusError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("status code %d: %v", err.StatusCode, err.Err)
+	}
+
+	return fmt.Sprintf("status code %d", err.StatusCode)
+}
+
+// Unwrap returns the reason why the body can't be decoded, or the decoded body of the response when it's an error,
+// so it can be retrieved using errors.As.
+func (err *StatusError) Unwrap() error {
+	if err.Err != nil {
+		return err.Err
+	}
+
+	if result, ok := err.Result.(error); ok {
+		return result
+	}
+
+	return nil
+}
+
+// Returns the target for the body of a response with statusCode, which is result unless req declares another one.
+func (req *BaseRequest) target(statusCode int, result any) any {
+	if target, found := req.StatusResults[statusCode]; found {
+		return target
+	}
+
+	return result
+}
+
+// Uses client to decode the body of response, which doesn't have the OK status code, and returns the error describing
+// it: a *StatusError with the decoded body when req declares a target for it, a *Problem when the response contains
+// problem details, or a *StatusError otherwise. When the body can't be read or decoded into the target, the
+// *StatusError wraps the reason, so the error is classified by its status code all the same.
+func (req *BaseRequest) statusError(client *http.Client, response *http.Response) error {
+	if target := req.target(response.StatusCode, req.ErrorResult); target != nil {
+		responseData, err := io.ReadAll(response.Body)
+
+		if err != nil {
+			return &StatusError{StatusCode: response.StatusCode, Err: fmt.Errorf("failed to read response body: %w", err)}
+		}
+
+		if err := req.decode(client, response, responseData, target); err != nil {
+			return &StatusError{StatusCode: response.StatusCode, Err: err}
+		}
+
+		return &StatusError{StatusCode: response.StatusCode, Result: target}
+	}
+
+	if isProblem(response) {
+		if problem, err := readProblem(response); err == nil {
+			return problem
+		}
+	}
+
+	return &StatusError{StatusCode: response.StatusCode}
+}
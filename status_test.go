@@ -0,0 +1,322 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/go-essentials/tstsrv"
+)
+
+// The error returned by the API.
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns the description of err.
+func (err *apiError) Error() string {
+	return err.Code + ": " + err.Message
+}
+
+// UT: Decode the body of responses depending on their status code.
+func TestStatusResults(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Response struct {
+		Id string `json:"id"`
+	}
+
+	type Job struct {
+		JobId string `json:"jobId"`
+	}
+
+	t.Run("When the response doesn't have the OK status code.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusBadRequest, Body: `{"code":"invalid_id","message":"The id is invalid."}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+		var statusErr *rapi.StatusError
+		var apiErr *apiError
+
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL(),
+				OkStatusCode: http.StatusOK,
+				ErrorResult:  &apiError{},
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest, "\n\n"+
+			"UT Name:  A 'rapi.StatusError' is returned when the response doesn't have the OK status code.\n"+
+			"\033[32mExpected: status code 400\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Truef(t, errors.As(err, &apiErr), "\n\n"+
+			"UT Name:  The error result is returned when the response doesn't have the OK status code.\n"+
+			"\033[32mExpected: *rapi_test.apiError\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, apiErr.Code, "invalid_id", "\n\n"+
+			"UT Name:  The body is decoded into the error result.\n"+
+			"\033[32mExpected: invalid_id\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", apiErr.Code)
+	})
+
+	t.Run("When the success type depends on the status code.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK, Body: `{"id":"0"}`},
+					{StatusCode: http.StatusAccepted, Body: `{"jobId":"1"}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+		var job Job
+
+		request := rapi.POSTRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:      srvFake.URL(),
+				OkStatusCodes: []int{http.StatusOK, http.StatusAccepted},
+				StatusResults: map[int]any{http.StatusAccepted: &job},
+			},
+		}
+
+		// ACT.
+		okErr := request.POST(http.DefaultClient, &got)
+		acceptedErr := request.POST(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, okErr, "\n\n"+
+			"UT Name:  NO 'error' is returned when the response has the OK status code.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", okErr)
+
+		assert.Nilf(t, acceptedErr, "\n\n"+
+			"UT Name:  NO 'error' is returned when the response has another OK status code.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", acceptedErr)
+
+		assert.Equalf(t, got, Response{Id: "0"}, "\n\n"+
+			"UT Name:  The body is decoded into the result when there's no target for the status code.\n"+
+			"\033[32mExpected: {Id:0}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", got)
+
+		assert.Equalf(t, job, Job{JobId: "1"}, "\n\n"+
+			"UT Name:  The body is decoded into the target for the status code.\n"+
+			"\033[32mExpected: {JobId:1}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", job)
+	})
+
+	t.Run("When the response doesn't have the OK status code, without error result.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusConflict, Body: `{"code":"conflict"}`},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got Response
+		var statusErr *rapi.StatusError
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &statusErr) && statusErr.Result == nil, "\n\n"+
+			"UT Name:  A 'rapi.StatusError' without result is returned when there's no error result.\n"+
+			"\033[32mExpected: status code 409\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
+
+// UT: Handle responses depending on their status code.
+func TestStatusHandlers(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	errExact := errors.New("exact")
+	errRange := errors.New("range")
+	errClass := errors.New("class")
+	errDefault := errors.New("default")
+
+	for _, tc := range []struct {
+		name       string
+		statusCode int
+		want       error
+	}{
+		{name: "When the exact status code is handled.", statusCode: http.StatusNotFound, want: errExact},
+		{name: "When the range of the status code is handled.", statusCode: http.StatusConflict, want: errRange},
+		{name: "When the class of the status code is handled.", statusCode: http.StatusBadRequest, want: errClass},
+		{name: "When the status code isn't handled.", statusCode: http.StatusBadGateway, want: errDefault},
+		{name: "When the status code is 501.", statusCode: http.StatusNotImplemented, want: errDefault},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// FAKE SETUP.
+			srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+				"/": {
+					Responses: []tstsrv.Response{
+						{StatusCode: tc.statusCode},
+					},
+				},
+			})
+
+			defer srvFake.Close()
+
+			// ARRANGE.
+			request := rapi.GETRequestMsg{
+				BaseRequest: rapi.BaseRequest{
+					Endpoint:               srvFake.URL(),
+					OkStatusCode:           http.StatusOK,
+					HttpStatusCodeHandlers: map[int]func() error{http.StatusNotFound: func() error { return errExact }},
+					StatusRangeHandlers: []rapi.StatusRangeHandler{
+						{First: http.StatusNotFound, Last: http.StatusConflict, Handler: func() error { return errRange }},
+					},
+					StatusClassHandlers:  map[int]func() error{4: func() error { return errClass }},
+					DefaultStatusHandler: func() error { return errDefault },
+					IgnoreNotImplemented: true,
+				},
+			}
+
+			// ACT.
+			err := request.GET(http.DefaultClient, nil)
+
+			// ASSERT.
+			assert.Truef(t, errors.Is(err, tc.want), "\n\n"+
+				"UT Name:  The handler with the highest precedence is used.\n"+
+				"\033[32mExpected: %v\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", tc.want, err)
+		})
+	}
+
+	t.Run("When the response has the OK status code.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusOK},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:             srvFake.URL(),
+				OkStatusCode:         http.StatusOK,
+				DefaultStatusHandler: func() error { return errDefault },
+			},
+		}
+
+		// ACT.
+		err := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  The default handler isn't used when the response has the OK status code.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the status code is 501.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+			"/": {
+				Responses: []tstsrv.Response{
+					{StatusCode: http.StatusNotImplemented},
+					{StatusCode: http.StatusNotImplemented},
+				},
+			},
+		})
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var statusErr *rapi.StatusError
+
+		request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL(), OkStatusCode: http.StatusOK}}
+
+		// ACT.
+		builtInErr := request.GET(http.DefaultClient, nil)
+		request.IgnoreNotImplemented = true
+		ignoredErr := request.GET(http.DefaultClient, nil)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(builtInErr, rapi.ErrNotImplemented), "\n\n"+
+			"UT Name:  'rapi.ErrNotImplemented' is returned when the status code is 501.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrNotImplemented, builtInErr)
+
+		assert.Truef(t, errors.As(ignoredErr, &statusErr), "\n\n"+
+			"UT Name:  A 'rapi.StatusError' is returned when the built-in handling of 501 is removed.\n"+
+			"\033[32mExpected: status code 501\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", ignoredErr)
+	})
+}
@@ -0,0 +1,304 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Matches the variables of a path template, such as "{id}".
+var pathVariablePattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// StructRequestMsg describes an HTTP request whose path, query, headers and body are defined by the fields of a struct.
+// The fields are tagged with `rapi:"path:<name>"`, `rapi:"query:<name>[,omitempty]"`,
+// `rapi:"header:<name>[,omitempty]"` or `rapi:"body"`. Slices expand to repeated query parameters and header values.
+type StructRequestMsg struct {
+	BaseRequest        // The "base" HTTP request. The path is appended to its endpoint.
+	Method      string // The HTTP method of the request.
+	Path        string // The path template of the request, such as "/users/{id}/orders".
+	Params      any    // The struct (or a pointer to it) defining the request.
+}
+
+// Do uses client to make the HTTP request described by req and updates result.
+// It return an error if any error occurs or <nil> when no error was returned.
+// The request is encoded before it's sent, so encoding errors are returned without making any request.
+func (req *StructRequestMsg) Do(client *http.Client, result any) error {
+	base, data, err := req.build()
+
+	if err != nil {
+		return req.wrapError(req.Method, err)
+	}
+
+	var body *payload
+
+	if data != nil {
+		body = textPayload(string(data), "application/json")
+	}
+
+	return base.exchange(client, req.Method, body, result)
+}
+
+// Returns the "base" HTTP request and the payload defined by the fields of req.Params.
+// It returns an error if the fields can't be encoded.
+func (req *StructRequestMsg) build() (BaseRequest, []byte, error) {
+	base := req.BaseRequest
+	base.HttpHeaders = maps.Clone(req.HttpHeaders)
+
+	if base.HttpHeaders == nil {
+		base.HttpHeaders = make(map[string]string)
+	}
+
+	params := reflect.ValueOf(req.Params)
+
+	for params.Kind() == reflect.Pointer && !params.IsNil() {
+		params = params.Elem()
+	}
+
+	if params.Kind() != reflect.Struct {
+		return base, nil, fmt.Errorf("failed to encode request: %T isn't a struct", req.Params)
+	}
+
+	pathValues := make(map[string]string)
+	base.Query = make(url.Values)
+
+	for key, values := range req.Query {
+		base.Query[key] = slices.Clone(values)
+	}
+
+	var data []byte
+
+	for i := range params.NumField() {
+		field, value := params.Type().Field(i), params.Field(i)
+		tag, found := field.Tag.Lookup("rapi")
+
+		if !found || !field.IsExported() {
+			continue
+		}
+
+		kind, name, _ := strings.Cut(tag, ":")
+		name, options, _ := strings.Cut(name, ",")
+
+		if options == "omitempty" && value.IsZero() {
+			continue
+		}
+
+		switch kind {
+		case "body":
+			var err error
+
+			if data, err = json.Marshal(value.Interface()); err != nil {
+				return base, nil, fmt.Errorf("failed to encode body: %w", err)
+			}
+
+		case "path":
+			values, err := formatValues(value)
+
+			if err == nil && len(values) != 1 {
+				err = errors.New("a path variable requires exactly one value")
+			}
+
+			if err != nil {
+				return base, nil, fmt.Errorf("failed to encode path variable %q: %w", name, err)
+			}
+
+			pathValues[name] = values[0]
+
+		case "query":
+			values, err := formatValues(value)
+
+			if err != nil {
+				return base, nil, fmt.Errorf("failed to encode query parameter %q: %w", name, err)
+			}
+
+			base.Query[name] = append(base.Query[name], values...)
+
+		case "header":
+			values, err := formatValues(value)
+
+			if err != nil {
+				return base, nil, fmt.Errorf("failed to encode header %q: %w", name, err)
+			}
+
+			if len(values) > 0 {
+				base.HttpHeaders[name] = strings.Join(values, ", ")
+			}
+
+		default:
+			return base, nil, fmt.Errorf("failed to encode request: invalid tag %q on field %s", tag, field.Name)
+		}
+	}
+
+	path, err := expandPath(req.Path, pathValues)
+
+	if err != nil {
+		return base, nil, err
+	}
+
+	if base.Endpoint, err = joinPath(req.Endpoint, path); err != nil {
+		return base, nil, err
+	}
+
+	return base, data, nil
+}
+
+// Returns endpoint with path, which is already escaped, appended to its path. The query and the fragment of endpoint
+// are kept.
+// It returns an error if endpoint isn't a valid URL.
+func joinPath(endpoint, path string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	escapedPath := strings.TrimSuffix(endpointURL.EscapedPath(), "/") + path
+
+	if endpointURL.Path, err = url.PathUnescape(escapedPath); err != nil {
+		return "", fmt.Errorf("failed to encode path: %w", err)
+	}
+
+	endpointURL.RawPath = escapedPath
+
+	return endpointURL.String(), nil
+}
+
+// Returns template with each variable replaced by its escaped value in values.
+// It returns an error if a variable doesn't have a value or if a value isn't used.
+func expandPath(template string, values map[string]string) (string, error) {
+	var err error
+
+	used := make(map[string]bool)
+	path := pathVariablePattern.ReplaceAllStringFunc(template, func(variable string) string {
+		name := variable[1 : len(variable)-1]
+		value, found := values[name]
+
+		if !found && err == nil {
+			err = fmt.Errorf("failed to encode path: no value for variable %q", name)
+		}
+
+		used[name] = true
+
+		return url.PathEscape(value)
+	})
+
+	for name := range values {
+		if !used[name] && err == nil {
+			err = fmt.Errorf("failed to encode path: variable %q isn't used in %q", name, template)
+		}
+	}
+
+	return path, err
+}
+
+// Returns the textual representations of value. Pointers are followed and slices are expanded into multiple values.
+// It returns an error if value has an unsupported type.
+func formatValues(value reflect.Value) ([]string, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		value = value.Elem()
+	}
+
+	if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && !isTextValue(value) {
+		values := make([]string, 0, value.Len())
+
+		for i := range value.Len() {
+			formatted, err := formatValues(value.Index(i))
+
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, formatted...)
+		}
+
+		return values, nil
+	}
+
+	formatted, err := formatValue(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{formatted}, nil
+}
+
+// Reports whether value formats itself as text.
+func isTextValue(value reflect.Value) bool {
+	switch value.Interface().(type) {
+	case encoding.TextMarshaler, fmt.Stringer:
+		return true
+	}
+
+	return false
+}
+
+// Returns the textual representation of value.
+// It returns an error if value has an unsupported type.
+func formatValue(value reflect.Value) (string, error) {
+	switch v := value.Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+
+		return string(text), err
+
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
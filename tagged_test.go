@@ -0,0 +1,232 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// The request as it's received by an echo server.
+type echoedRequest struct {
+	Method  string              `json:"method"`  // The HTTP method.
+	URI     string              `json:"uri"`     // The request URI.
+	Headers map[string][]string `json:"headers"` // The HTTP headers.
+	Body    string              `json:"body"`    // The body.
+}
+
+// Returns a server which responds with the JSON encoded echoedRequest for each request and counts them in count.
+func newEchoServer(count *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+
+		body, _ := io.ReadAll(r.Body)
+
+		json.NewEncoder(w).Encode(echoedRequest{
+			Method:  r.Method,
+			URI:     r.RequestURI,
+			Headers: r.Header,
+			Body:    string(body),
+		})
+	}))
+}
+
+// UT: Make an HTTP request defined by a struct.
+func TestStructRequestMsg(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	type Order struct {
+		Item string `json:"item"`
+	}
+
+	type CreateOrder struct {
+		UserId string   `rapi:"path:id"`
+		Page   int      `rapi:"query:page,omitempty"`
+		Tags   []string `rapi:"query:tag"`
+		Tenant string   `rapi:"header:X-Tenant"`
+		Order  Order    `rapi:"body"`
+	}
+
+	t.Run("When the struct is valid.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		request := rapi.StructRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL + "/", OkStatusCode: http.StatusOK},
+			Method:      http.MethodPost,
+			Path:        "/users/{id}/orders",
+			Params: CreateOrder{
+				UserId: "a/b c",
+				Tags:   []string{"x&y", "z"},
+				Tenant: "acme",
+				Order:  Order{Item: "book"},
+			},
+		}
+
+		// ACT.
+		err := request.Do(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the struct is valid.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got.URI, "/users/a%2Fb%20c/orders?tag=x%26y&tag=z", "\n\n"+
+			"UT Name:  The path is escaped and the query parameters are expanded.\n"+
+			"\033[32mExpected: /users/a%%2Fb%%20c/orders?tag=x%%26y&tag=z\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.URI)
+
+		assert.Equalf(t, got.Headers["X-Tenant"][0], "acme", "\n\n"+
+			"UT Name:  The header is sent.\n"+
+			"\033[32mExpected: acme\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Headers["X-Tenant"][0])
+
+		assert.Equalf(t, got.Body, `{"item":"book"}`, "\n\n"+
+			"UT Name:  The body is sent as JSON.\n"+
+			"\033[32mExpected: {\"item\":\"book\"}\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got.Body)
+	})
+
+	t.Run("When the endpoint has a query.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		request := rapi.StructRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL + "/api?api-version=2", OkStatusCode: http.StatusOK},
+			Method:      http.MethodPost,
+			Path:        "/users/{id}/orders",
+			Params:      CreateOrder{UserId: "a/b", Tags: []string{"z"}},
+		}
+
+		// ACT.
+		err := request.Do(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.Truef(t, err == nil && got.URI == "/api/users/a%2Fb/orders?api-version=2&tag=z", "\n\n"+
+			"UT Name:  The path is appended to the path of the endpoint, and its query is kept.\n"+
+			"\033[32mExpected: /api/users/a%%2Fb/orders?api-version=2&tag=z <nil>\033[0m\n"+
+			"\033[31mActual:   %s %v\033[0m\n\n", got.URI, err)
+	})
+
+	t.Run("When a path variable doesn't have a value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		request := rapi.StructRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK},
+			Method:      http.MethodPost,
+			Path:        "/users/{id}/orders/{orderId}",
+			Params:      CreateOrder{UserId: "0"},
+		}
+
+		// ACT.
+		err := request.Do(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when a path variable doesn't have a value.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, count.Load(), 0, "\n\n"+
+			"UT Name:  NO request is made when the struct can't be encoded.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+	})
+
+	t.Run("When a field has an unsupported type.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		var count atomic.Int32
+
+		srvFake := newEchoServer(&count)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var got echoedRequest
+
+		request := rapi.StructRequestMsg{
+			BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL, OkStatusCode: http.StatusOK},
+			Method:      http.MethodGet,
+			Path:        "/",
+			Params: struct {
+				Filter map[string]string `rapi:"query:filter"`
+			}{Filter: map[string]string{"a": "b"}},
+		}
+
+		// ACT.
+		err := request.Do(http.DefaultClient, &got)
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when a field has an unsupported type.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, count.Load(), 0, "\n\n"+
+			"UT Name:  NO request is made when the struct can't be encoded.\n"+
+			"\033[32mExpected: 0\033[0m\n"+
+			"\033[31mActual:   %d\033[0m\n\n", count.Load())
+	})
+}
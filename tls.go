@@ -0,0 +1,272 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TLSConfig describes the TLS configuration of an HTTP client.
+// The certificates are read from disk and reloaded when the files change, so rotated certificates are picked up by
+// new connections without recreating the client.
+type TLSConfig struct {
+	CertFile string   // The PEM encoded client certificate.
+	KeyFile  string   // The PEM encoded private key of the client certificate.
+	CAFiles  []string // The PEM encoded CA bundles to trust. When empty, the system's CA bundle is used.
+	SPKIPins []string // The base64 encoded SHA-256 hashes of the pinned public keys (see SPKIHash).
+}
+
+// PinMismatchError is returned when none of the certificates presented by a server matches a pinned public key.
+type PinMismatchError struct {
+	ServerName string   // The name of the server.
+	Hashes     []string // The SPKI hashes of the certificates presented by the server.
+}
+
+// Error returns the description of err.
+func (err *PinMismatchError) Error() string {
+	return fmt.Sprintf("certificate pin mismatch for %q: got %v", err.ServerName, err.Hashes)
+}
+
+// SPKIHash returns the base64 encoded SHA-256 hash of the Subject Public Key Info of cert.
+func SPKIHash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// Client returns an *http.Client which uses the TLS configuration described by cfg.
+// The server's certificate is verified against the host which is dialed, using the CA bundles as they are on disk
+// when the connection is made.
+// It returns an error if the certificates can't be loaded.
+func (cfg *TLSConfig) Client() (*http.Client, error) {
+	tlsConfig, files, err := cfg.build()
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if len(cfg.CAFiles) > 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+		transport.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(addr)
+
+			if err != nil {
+				return nil, err
+			}
+
+			dialConfig := transport.TLSClientConfig.Clone()
+			_, dialConfig.RootCAs = files.get()
+
+			if dialConfig.ServerName == "" {
+				dialConfig.ServerName = host
+			}
+
+			return (&tls.Dialer{NetDialer: dialer, Config: dialConfig}).DialContext(ctx, network, addr)
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// Build returns the *tls.Config described by cfg.
+// The CA bundles are the ones loaded when Build is called; use Client to pick up rotated CA bundles.
+// It returns an error if the certificates can't be loaded.
+func (cfg *TLSConfig) Build() (*tls.Config, error) {
+	tlsConfig, _, err := cfg.build()
+
+	return tlsConfig, err
+}
+
+// Returns the *tls.Config described by cfg and the files it's loaded from.
+// It returns an error if the certificates can't be loaded.
+func (cfg *TLSConfig) build() (*tls.Config, *tlsFiles, error) {
+	files := &tlsFiles{cfg: cfg}
+
+	if err := files.reload(); err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: files.roots}
+
+	if cfg.CertFile != "" {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := files.get()
+
+			return cert, nil
+		}
+	}
+
+	tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+		return cfg.verifyPins(state)
+	}
+
+	return tlsConfig, files, nil
+}
+
+// Verifies that one of the certificates in the verified chains of state matches a pinned public key.
+// It returns an error if there is no such certificate or <nil> when no public keys are pinned.
+func (cfg *TLSConfig) verifyPins(state tls.ConnectionState) error {
+	if len(cfg.SPKIPins) == 0 {
+		return nil
+	}
+
+	var hashes []string
+
+	for _, chain := range state.VerifiedChains {
+		for _, cert := range chain {
+			hash := SPKIHash(cert)
+
+			if slices.Contains(cfg.SPKIPins, hash) {
+				return nil
+			}
+
+			if !slices.Contains(hashes, hash) {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+
+	return &PinMismatchError{ServerName: state.ServerName, Hashes: hashes}
+}
+
+// The certificates described by a TLSConfig, reloaded when the files on disk change.
+type tlsFiles struct {
+	cfg      *TLSConfig           // The configuration describing the files.
+	lock     sync.Mutex           // Protect concurrent access to the loaded certificates.
+	modTimes map[string]time.Time // The modification time of each file when it was loaded.
+	cert     *tls.Certificate     // The client certificate.
+	roots    *x509.CertPool       // The trusted CA certificates.
+}
+
+// Returns the client certificate and the trusted CA certificates.
+// The files are reloaded first if they were modified. If reloading fails, the previous certificates are returned, so
+// that a file which is only partially written during a rotation doesn't break the client.
+func (files *tlsFiles) get() (*tls.Certificate, *x509.CertPool) {
+	files.lock.Lock()
+	defer files.lock.Unlock()
+
+	if files.modified() {
+		_ = files.load()
+	}
+
+	return files.cert, files.roots
+}
+
+// Loads the files described by files.cfg.
+// It returns an error if any of the files can't be loaded.
+func (files *tlsFiles) reload() error {
+	files.lock.Lock()
+	defer files.lock.Unlock()
+
+	return files.load()
+}
+
+// Returns the paths of all the files described by files.cfg.
+func (files *tlsFiles) paths() []string {
+	paths := slices.Clone(files.cfg.CAFiles)
+
+	if files.cfg.CertFile != "" {
+		paths = append(paths, files.cfg.CertFile, files.cfg.KeyFile)
+	}
+
+	return paths
+}
+
+// Reports whether any of the files was modified since it was loaded.
+func (files *tlsFiles) modified() bool {
+	for _, path := range files.paths() {
+		if info, err := os.Stat(path); err == nil && !info.ModTime().Equal(files.modTimes[path]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Loads the files described by files.cfg.
+// It returns an error if any of the files can't be loaded, in which case the previous certificates are kept.
+func (files *tlsFiles) load() error {
+	modTimes := make(map[string]time.Time)
+
+	for _, path := range files.paths() {
+		info, err := os.Stat(path)
+
+		if err != nil {
+			return fmt.Errorf("failed to load TLS file: %w", err)
+		}
+
+		modTimes[path] = info.ModTime()
+	}
+
+	var cert *tls.Certificate
+
+	if files.cfg.CertFile != "" {
+		pair, err := tls.LoadX509KeyPair(files.cfg.CertFile, files.cfg.KeyFile)
+
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		cert = &pair
+	}
+
+	var roots *x509.CertPool
+
+	if len(files.cfg.CAFiles) > 0 {
+		roots = x509.NewCertPool()
+
+		for _, path := range files.cfg.CAFiles {
+			data, err := os.ReadFile(path)
+
+			if err != nil {
+				return fmt.Errorf("failed to load CA bundle: %w", err)
+			}
+
+			if !roots.AppendCertsFromPEM(data) {
+				return fmt.Errorf("failed to load CA bundle: no certificates found in %q", path)
+			}
+		}
+	}
+
+	files.modTimes, files.cert, files.roots = modTimes, cert, roots
+
+	return nil
+}
@@ -0,0 +1,363 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// A certificate and its private key, used for testing.
+type testCert struct {
+	cert *x509.Certificate // The certificate.
+	key  *ecdsa.PrivateKey // The private key.
+}
+
+// Returns a new certificate with the common name cn, signed by issuer. When issuer is <nil>, a CA is returned.
+// The certificate is issued for hosts, or for 127.0.0.1 when no hosts are given.
+func newTestCert(t *testing.T, cn string, issuer *testCert, hosts ...string) testCert {
+	t.Helper()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if len(hosts) == 0 {
+		hosts = []string{"127.0.0.1"}
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	parent, signer := template, key
+
+	if issuer == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		parent, signer = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, _ := x509.ParseCertificate(der)
+
+	return testCert{cert: cert, key: key}
+}
+
+// Writes the PEM encoded certificate and private key of c to dir and returns their paths.
+func (c testCert) write(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	keyDer, _ := x509.MarshalECPrivateKey(c.key)
+	certPath, keyPath := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+
+	os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600)
+	os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)
+
+	return certPath, keyPath
+}
+
+// Returns a TLS server, signed by ca, which requires a client certificate signed by ca and which responds with the
+// common name of the client certificate.
+func newTLSServer(ca, server testCert) *httptest.Server {
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.cert.Raw}, PrivateKey: server.key}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+
+	srv.StartTLS()
+
+	return srv
+}
+
+// UT: Make HTTP requests using a TLS configuration.
+func TestTLSConfig(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When a file can't be loaded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// ARRANGE.
+		cfg := rapi.TLSConfig{CAFiles: []string{filepath.Join(t.TempDir(), "missing.crt")}}
+
+		// ACT.
+		_, err := cfg.Client()
+
+		// ASSERT.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  An 'error' is returned when a file can't be loaded.\n"+
+			"\033[32mExpected: NOT <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the client certificate is signed by a trusted CA.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		ca := newTestCert(t, "CA", nil)
+		srvFake := newTLSServer(ca, newTestCert(t, "server", &ca))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		dir := t.TempDir()
+		caFile, _ := ca.write(t, dir, "ca")
+		certFile, keyFile := newTestCert(t, "client", &ca).write(t, dir, "client")
+
+		var got string
+
+		cfg := rapi.TLSConfig{CertFile: certFile, KeyFile: keyFile, CAFiles: []string{caFile}}
+		client, _ := cfg.Client()
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(client, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the client certificate is trusted.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got, "client", "\n\n"+
+			"UT Name:  The client certificate is sent to the server.\n"+
+			"\033[32mExpected: client\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got)
+	})
+
+	t.Run("When the server's certificate isn't issued for the host.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		ca := newTestCert(t, "CA", nil)
+		srvFake := newTLSServer(ca, newTestCert(t, "server", &ca, "evil.example"))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		dir := t.TempDir()
+		caFile, _ := ca.write(t, dir, "ca")
+		certFile, keyFile := newTestCert(t, "client", &ca).write(t, dir, "client")
+
+		var got string
+		var hostErr x509.HostnameError
+
+		cfg := rapi.TLSConfig{CertFile: certFile, KeyFile: keyFile, CAFiles: []string{caFile}}
+		client, _ := cfg.Client()
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(client, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &hostErr), "\n\n"+
+			"UT Name:  An 'x509.HostnameError' is returned when the server's certificate isn't issued for the host.\n"+
+			"\033[32mExpected: x509.HostnameError\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the client certificate is rotated.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		ca := newTestCert(t, "CA", nil)
+		srvFake := newTLSServer(ca, newTestCert(t, "server", &ca))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		dir := t.TempDir()
+		caFile, _ := ca.write(t, dir, "ca")
+		certFile, keyFile := newTestCert(t, "client", &ca).write(t, dir, "client")
+
+		var got string
+
+		cfg := rapi.TLSConfig{CertFile: certFile, KeyFile: keyFile, CAFiles: []string{caFile}}
+		client, _ := cfg.Client()
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		request.GETPlain(client, &got)
+		newTestCert(t, "rotated client", &ca).write(t, dir, "client")
+		os.Chtimes(certFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+		client.CloseIdleConnections()
+
+		// ACT.
+		err := request.GETPlain(client, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the client certificate is rotated.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, got, "rotated client", "\n\n"+
+			"UT Name:  The rotated client certificate is sent to the server.\n"+
+			"\033[32mExpected: rotated client\033[0m\n"+
+			"\033[31mActual:   %s\033[0m\n\n", got)
+	})
+
+	t.Run("When the server's public key is pinned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		ca := newTestCert(t, "CA", nil)
+		server := newTestCert(t, "server", &ca)
+		srvFake := newTLSServer(ca, server)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		dir := t.TempDir()
+		caFile, _ := ca.write(t, dir, "ca")
+		certFile, keyFile := newTestCert(t, "client", &ca).write(t, dir, "client")
+
+		var got string
+
+		cfg := rapi.TLSConfig{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+			CAFiles:  []string{caFile},
+			SPKIPins: []string{rapi.SPKIHash(server.cert)},
+		}
+
+		client, _ := cfg.Client()
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(client, &got)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the server's public key matches the pin.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the server's public key doesn't match the pin.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		ca := newTestCert(t, "CA", nil)
+		srvFake := newTLSServer(ca, newTestCert(t, "server", &ca))
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		dir := t.TempDir()
+		caFile, _ := ca.write(t, dir, "ca")
+		certFile, keyFile := newTestCert(t, "client", &ca).write(t, dir, "client")
+
+		var got string
+		var pinErr *rapi.PinMismatchError
+
+		cfg := rapi.TLSConfig{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+			CAFiles:  []string{caFile},
+			SPKIPins: []string{rapi.SPKIHash(newTestCert(t, "other", nil).cert)},
+		}
+
+		client, _ := cfg.Client()
+		request := rapi.GETRequestMsg{
+			BaseRequest: rapi.BaseRequest{
+				Endpoint:     srvFake.URL,
+				OkStatusCode: http.StatusOK,
+			},
+		}
+
+		// ACT.
+		err := request.GETPlain(client, &got)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &pinErr), "\n\n"+
+			"UT Name:  A '*rapi.PinMismatchError' is returned when the server's public key doesn't match the pin.\n"+
+			"\033[32mExpected: *rapi.PinMismatchError\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+}
@@ -0,0 +1,168 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// ErrMissingETag is returned when the resource to update doesn't have an ETag.
+var ErrMissingETag = errors.New("missing ETag")
+
+// UpdateRequestMsg describes an update of a resource using optimistic concurrency: the resource is fetched using an
+// HTTP GET request, modified, and stored using an HTTP PUT (or PATCH) request which only succeeds when the resource
+// didn't change in between (see RFC 9110, section 13.1.1). When it did change, the update is tried again.
+type UpdateRequestMsg struct {
+	BaseRequest        // The "base" HTTP request, used to fetch and store the resource.
+	Method      string // The HTTP method used to store the resource: PUT (the default) or PATCH, which sends a JSON Merge Patch.
+	MaxAttempts int    // The maximum number of attempts. Defaults to 3.
+}
+
+// Update uses client to update the resource described by req using mutate, which modifies the fetched resource.
+// The resource is fetched with OK status code 200, and stored as JSON with the OK status codes of req (or any 2xx
+// status code when req doesn't have one). When the resource is changed by someone else before it's stored, it's
+// fetched and modified again, up to req.MaxAttempts times. It returns the stored resource, which is the body of the
+// response to the HTTP PUT (or PATCH) request, or the modified resource when that body is empty.
+// It return an error if any error occurs or <nil> when no error was returned.
+func Update[T any](client *http.Client, req *UpdateRequestMsg, mutate func(resource *T) error) (*T, error) {
+	maxAttempts := req.MaxAttempts
+
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		var resource T
+
+		etag, err := req.fetch(client, &resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Snapshot the resource as JSON, since mutate can modify the maps and slices it shares with a copy.
+		original, err := json.Marshal(resource)
+
+		if err != nil {
+			return nil, req.wrapError(req.method(), fmt.Errorf("failed to marshal JSON: %w", err))
+		}
+
+		if err := mutate(&resource); err != nil {
+			return nil, err
+		}
+
+		err = req.store(client, etag, json.RawMessage(original), &resource)
+
+		if statusCode, ok := errorStatusCode(err); attempt < maxAttempts && ok && statusCode == http.StatusPreconditionFailed {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &resource, nil
+	}
+}
+
+// Returns the HTTP method used to store the resource.
+func (req *UpdateRequestMsg) method() string {
+	if req.Method == "" {
+		return http.MethodPut
+	}
+
+	return req.Method
+}
+
+// Uses client to fetch the resource described by req into resource, and returns its ETag.
+// It returns an error if the resource can't be fetched or decoded, or ErrMissingETag when it doesn't have an ETag.
+func (req *UpdateRequestMsg) fetch(client *http.Client, resource any) (string, error) {
+	base := req.BaseRequest
+	base.OkStatusCode, base.OkStatusCodes = http.StatusOK, nil
+
+	responseData, response, err := base.do(client, http.MethodGet, nil)
+
+	if err != nil {
+		return "", req.wrapError(http.MethodGet, err)
+	}
+
+	if err := base.decode(client, response, responseData, resource); err != nil {
+		return "", req.wrapError(http.MethodGet, err)
+	}
+
+	etag := response.Header.Get("ETag")
+
+	if etag == "" {
+		return "", req.wrapError(http.MethodGet, ErrMissingETag)
+	}
+
+	return etag, nil
+}
+
+// Uses client to store resource, which is modified from original with etag, as described by req. The resource is
+// sent as a JSON Merge Patch document of the modifications when it's stored using an HTTP PATCH request, or as JSON
+// otherwise. The response updates resource.
+// It returns an error if the resource can't be encoded, or if it can't be stored (see StatusError and Problem).
+func (req *UpdateRequestMsg) store(client *http.Client, etag string, original, resource any) error {
+	var body *payload
+
+	if req.method() == http.MethodPatch {
+		data, err := DiffMergePatch(original, resource)
+
+		if err != nil {
+			return req.wrapError(req.method(), err)
+		}
+
+		body = textPayload(string(data), MergePatchContentType)
+	} else {
+		data, err := json.Marshal(resource)
+
+		if err != nil {
+			return req.wrapError(req.method(), fmt.Errorf("failed to marshal JSON: %w", err))
+		}
+
+		body = textPayload(string(data), "application/json")
+	}
+
+	base := req.BaseRequest
+	base.HttpHeaders = maps.Clone(req.HttpHeaders)
+
+	if base.HttpHeaders == nil {
+		base.HttpHeaders = make(map[string]string)
+	}
+
+	base.HttpHeaders["If-Match"] = etag
+
+	if base.OkStatusCode == 0 && len(base.OkStatusCodes) == 0 {
+		base.OkStatusCodes = StatusRange(200, 299)
+	}
+
+	return base.exchange(client, req.method(), body, resource)
+}
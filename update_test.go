@@ -0,0 +1,225 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+)
+
+// A versioned resource.
+type counter struct {
+	Value int `json:"value"`
+}
+
+// Returns a server which stores a counter, of which the ETag is its version. The first conflicts requests to store
+// it are rejected with a 412, as if the counter was changed by someone else in between, which is described by an
+// "application/problem+json" document when problem is true.
+func newCounterServer(conflicts int, etag, problem bool) *httptest.Server {
+	var lock sync.Mutex
+	var version int
+	var resource counter
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if r.Method == http.MethodGet {
+			if etag {
+				w.Header().Set("ETag", fmt.Sprintf(`"%d"`, version))
+			}
+
+			json.NewEncoder(w).Encode(resource)
+
+			return
+		}
+
+		if conflicts > 0 {
+			conflicts--
+			version++
+			resource.Value += 10
+		}
+
+		if r.Header.Get("If-Match") != fmt.Sprintf(`"%d"`, version) {
+			if problem {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(http.StatusPreconditionFailed)
+				fmt.Fprint(w, `{"title":"Precondition Failed","status":412}`)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusPreconditionFailed)
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &resource)
+		version++
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+// UT: Update a resource using optimistic concurrency.
+func TestUpdate(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	increment := func(resource *counter) error {
+		resource.Value++
+
+		return nil
+	}
+
+	t.Run("When the resource is changed by someone else.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCounterServer(2, true, false)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		request := rapi.UpdateRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL}}
+
+		// ACT.
+		got, err := rapi.Update(http.DefaultClient, &request, increment)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when the update succeeds within the maximum number of attempts.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, *got, counter{Value: 21}, "\n\n"+
+			"UT Name:  The resource is modified again after every conflict.\n"+
+			"\033[32mExpected: {Value:21}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", *got)
+	})
+
+	t.Run("When the conflict is described by a problem.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCounterServer(2, true, true)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		request := rapi.UpdateRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL}}
+
+		// ACT.
+		got, err := rapi.Update(http.DefaultClient, &request, increment)
+
+		// ASSERT.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  NO 'error' is returned when a conflict is described by an 'application/problem+json' document.\n"+
+			"\033[32mExpected: <nil>\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+
+		assert.Equalf(t, *got, counter{Value: 21}, "\n\n"+
+			"UT Name:  The resource is modified again after every conflict which is described by a problem.\n"+
+			"\033[32mExpected: {Value:21}\033[0m\n"+
+			"\033[31mActual:   %+v\033[0m\n\n", *got)
+	})
+
+	t.Run("When the resource keeps changing.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCounterServer(3, true, false)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		var statusErr *rapi.StatusError
+
+		request := rapi.UpdateRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL}, Method: http.MethodPatch}
+
+		// ACT.
+		_, err := rapi.Update(http.DefaultClient, &request, increment)
+
+		// ASSERT.
+		assert.Truef(t, errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusPreconditionFailed, "\n\n"+
+			"UT Name:  A 'rapi.StatusError' is returned when the maximum number of attempts is reached.\n"+
+			"\033[32mExpected: status code 412\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", err)
+	})
+
+	t.Run("When the resource doesn't have an ETag.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCounterServer(0, false, false)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		request := rapi.UpdateRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL}}
+
+		// ACT.
+		_, err := rapi.Update(http.DefaultClient, &request, increment)
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, rapi.ErrMissingETag), "\n\n"+
+			"UT Name:  'rapi.ErrMissingETag' is returned when the resource doesn't have an ETag.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", rapi.ErrMissingETag, err)
+	})
+
+	t.Run("When the modification fails.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// FAKE SETUP.
+		srvFake := newCounterServer(0, true, false)
+
+		defer srvFake.Close()
+
+		// ARRANGE.
+		errMutate := errors.New("mutate")
+		request := rapi.UpdateRequestMsg{BaseRequest: rapi.BaseRequest{Endpoint: srvFake.URL}}
+
+		// ACT.
+		_, err := rapi.Update(http.DefaultClient, &request, func(*counter) error { return errMutate })
+
+		// ASSERT.
+		assert.Truef(t, errors.Is(err, errMutate), "\n\n"+
+			"UT Name:  The 'error' of the modification is returned.\n"+
+			"\033[32mExpected: %v\033[0m\n"+
+			"\033[31mActual:   %v\033[0m\n\n", errMutate, err)
+	})
+}
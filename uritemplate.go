@@ -0,0 +1,368 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+package rapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// URIPair is a key and value pair of an associative array in a URI template.
+// A slice of pairs keeps its order when it's expanded, while a map is expanded in the order of its keys.
+type URIPair struct {
+	Key   string // The key.
+	Value string // The value.
+}
+
+// The behavior of an operator of a URI template expression (see RFC 6570, appendix A).
+type uriOperator struct {
+	first    string // The string to prepend to the expansion, if it isn't empty.
+	sep      string // The separator between the values.
+	named    bool   // Whether the values are prefixed with their name.
+	ifEmpty  string // The string to append to the name of an empty value.
+	reserved bool   // Whether reserved characters are allowed in the values.
+}
+
+// The operators of a URI template expression.
+var uriOperators = map[byte]uriOperator{
+	0:   {first: "", sep: ","},
+	'+': {first: "", sep: ",", reserved: true},
+	'#': {first: "#", sep: ",", reserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+// ExpandURITemplate returns the URI template (see RFC 6570, levels 1 to 4) expanded with vars.
+// Variables are strings (or any other scalar value supported by StructRequestMsg), lists (slices) or associative
+// arrays ([]URIPair or maps). Variables which are missing, <nil> or empty lists or associative arrays are undefined.
+// It returns an error if the template is malformed or if a variable can't be expanded.
+func ExpandURITemplate(template string, vars map[string]any) (string, error) {
+	var builder strings.Builder
+
+	for len(template) > 0 {
+		start := strings.IndexByte(template, '{')
+
+		if start < 0 {
+			start = len(template)
+		}
+
+		if end := strings.IndexByte(template[:start], '}'); end >= 0 {
+			return "", fmt.Errorf("invalid URI template: unexpected '}' at %q", template[end:])
+		}
+
+		builder.WriteString(encodeURIValue(template[:start], true))
+
+		if start == len(template) {
+			break
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+
+		if end < 0 {
+			return "", fmt.Errorf("invalid URI template: unclosed expression %q", template[start:])
+		}
+
+		if err := expandURIExpression(&builder, template[start+1:start+end], vars); err != nil {
+			return "", err
+		}
+
+		template = template[start+end+1:]
+	}
+
+	return builder.String(), nil
+}
+
+// ExpandEndpoint sets the endpoint of req to the URI template (see RFC 6570) expanded with vars.
+// It returns an error if the template can't be expanded, in which case the endpoint is left untouched.
+func (req *BaseRequest) ExpandEndpoint(template string, vars map[string]any) error {
+	endpoint, err := ExpandURITemplate(template, vars)
+
+	if err != nil {
+		return err
+	}
+
+	req.Endpoint = endpoint
+
+	return nil
+}
+
+// Writes the expansion of the URI template expression (without braces) to builder.
+// It returns an error if the expression is malformed or if a variable can't be expanded.
+func expandURIExpression(builder *strings.Builder, expression string, vars map[string]any) error {
+	var opKey byte
+
+	if expression != "" && strings.IndexByte("+#./;?&", expression[0]) >= 0 {
+		opKey, expression = expression[0], expression[1:]
+	} else if expression != "" && strings.IndexByte("=,!@|", expression[0]) >= 0 {
+		return fmt.Errorf("invalid URI template: reserved operator %q", expression[0])
+	}
+
+	op, first := uriOperators[opKey], true
+
+	for varSpec := range strings.SplitSeq(expression, ",") {
+		name, explode, prefix, err := parseURIVarSpec(varSpec)
+
+		if err != nil {
+			return err
+		}
+
+		value, defined, err := uriValue(vars[name])
+
+		if err != nil {
+			return fmt.Errorf("failed to expand URI variable %q: %w", name, err)
+		}
+
+		if !defined {
+			continue
+		}
+
+		if first {
+			builder.WriteString(op.first)
+			first = false
+		} else {
+			builder.WriteString(op.sep)
+		}
+
+		switch value := value.(type) {
+		case string:
+			if prefix > 0 {
+				if runes := []rune(value); len(runes) > prefix {
+					value = string(runes[:prefix])
+				}
+			}
+
+			writeURIPair(builder, op, name, value)
+
+		case []string:
+			if prefix > 0 {
+				return fmt.Errorf("invalid URI template: prefix on list variable %q", name)
+			}
+
+			if explode {
+				for i, item := range value {
+					if i > 0 {
+						builder.WriteString(op.sep)
+					}
+
+					if op.named {
+						writeURIPair(builder, op, name, item)
+					} else {
+						builder.WriteString(encodeURIValue(item, op.reserved))
+					}
+				}
+			} else {
+				if op.named {
+					builder.WriteString(name + "=")
+				}
+
+				for i, item := range value {
+					if i > 0 {
+						builder.WriteString(",")
+					}
+
+					builder.WriteString(encodeURIValue(item, op.reserved))
+				}
+			}
+
+		case []URIPair:
+			if prefix > 0 {
+				return fmt.Errorf("invalid URI template: prefix on associative array variable %q", name)
+			}
+
+			if explode {
+				for i, pair := range value {
+					if i > 0 {
+						builder.WriteString(op.sep)
+					}
+
+					if op.named {
+						writeURIPair(builder, op, encodeURIValue(pair.Key, op.reserved), pair.Value)
+					} else {
+						builder.WriteString(encodeURIValue(pair.Key, op.reserved) + "=" + encodeURIValue(pair.Value, op.reserved))
+					}
+				}
+			} else {
+				if op.named {
+					builder.WriteString(name + "=")
+				}
+
+				for i, pair := range value {
+					if i > 0 {
+						builder.WriteString(",")
+					}
+
+					builder.WriteString(encodeURIValue(pair.Key, op.reserved) + "," + encodeURIValue(pair.Value, op.reserved))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Writes value, prefixed with name if the operator op requires it, to builder.
+func writeURIPair(builder *strings.Builder, op uriOperator, name, value string) {
+	if op.named {
+		builder.WriteString(name)
+
+		if value == "" {
+			builder.WriteString(op.ifEmpty)
+
+			return
+		}
+
+		builder.WriteString("=")
+	}
+
+	builder.WriteString(encodeURIValue(value, op.reserved))
+}
+
+// Returns the name, the explode modifier and the prefix length of the URI template variable specification varSpec.
+// It returns an error if varSpec is malformed.
+func parseURIVarSpec(varSpec string) (string, bool, int, error) {
+	name, explode := strings.CutSuffix(varSpec, "*")
+	name, prefixText, hasPrefix := strings.Cut(name, ":")
+
+	if name == "" || strings.ContainsAny(name, " {}*") {
+		return "", false, 0, fmt.Errorf("invalid URI template: invalid variable %q", varSpec)
+	}
+
+	if !hasPrefix {
+		return name, explode, 0, nil
+	}
+
+	prefix, err := strconv.Atoi(prefixText)
+
+	if err != nil || explode || prefix <= 0 || prefix >= 10000 {
+		return "", false, 0, fmt.Errorf("invalid URI template: invalid prefix in %q", varSpec)
+	}
+
+	return name, false, prefix, nil
+}
+
+// Returns value as a string, a []string (a list) or a []URIPair (an associative array), and whether it's defined.
+// It returns an error if value has an unsupported type.
+func uriValue(value any) (any, bool, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, false, nil
+
+	case []URIPair:
+		return value, len(value) > 0, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	for reflected.Kind() == reflect.Pointer {
+		if reflected.IsNil() {
+			return nil, false, nil
+		}
+
+		reflected = reflected.Elem()
+	}
+
+	if reflected.Kind() == reflect.Map {
+		pairs := make([]URIPair, 0, reflected.Len())
+
+		for key, item := range reflected.Seq2() {
+			formattedKey, err := formatValue(key)
+
+			if err != nil {
+				return nil, false, err
+			}
+
+			formattedItem, err := formatValues(item)
+
+			if err != nil {
+				return nil, false, err
+			}
+
+			pairs = append(pairs, URIPair{Key: formattedKey, Value: strings.Join(formattedItem, ",")})
+		}
+
+		slices.SortFunc(pairs, func(a, b URIPair) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+
+		return pairs, len(pairs) > 0, nil
+	}
+
+	isList := (reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array) && !isTextValue(reflected)
+	values, err := formatValues(reflected)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if isList {
+		return values, len(values) > 0, nil
+	}
+
+	return values[0], true, nil
+}
+
+// Returns value with the characters that aren't allowed percent-encoded.
+// Unreserved characters are always allowed. When reserved is true, reserved characters and percent-encoded triplets
+// are allowed as well.
+func encodeURIValue(value string, reserved bool) string {
+	const hex = "0123456789ABCDEF"
+
+	var builder strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~", c) >= 0:
+			builder.WriteByte(c)
+
+		case reserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			builder.WriteByte(c)
+
+		case reserved && c == '%' && i+2 < len(value) && isHex(value[i+1]) && isHex(value[i+2]):
+			builder.WriteString(value[i : i+3])
+			i += 2
+
+		default:
+			builder.WriteByte('%')
+			builder.WriteByte(hex[c>>4])
+			builder.WriteByte(hex[c&0x0F])
+		}
+	}
+
+	return builder.String()
+}
+
+// Reports whether c is a hexadecimal digit.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
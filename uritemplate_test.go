@@ -0,0 +1,272 @@
+// =====================================================================================================================
+// == LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// ==
+// ==                Permission is hereby granted, free of charge, to any person
+// ==                obtaining a copy of this software and associated documentation
+// ==                files (the "Software"), to deal in the Software without
+// ==                restriction, including without limitation the rights to use,
+// ==                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// ==                copies of the Software, and to permit persons to whom the
+// ==                Software is furnished to do so, subject to the following
+// ==                conditions:
+// ==
+// ==                The above copyright notice and this permission notice shall be
+// ==                included in all copies or substantial portions of the Software.
+// ==
+// ==                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// ==                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// ==                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// ==                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// ==                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// ==                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// ==                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// ==                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Quality assurance: Verify (and measure the performance) of the public API of the "rapi" package.
+package rapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-essentials/assert"
+	"github.com/go-essentials/rapi"
+	"github.com/go-essentials/tstsrv"
+)
+
+// The variables used by the examples of RFC 6570, section 3.2.
+var rfc6570Vars = map[string]any{
+	"count":      []string{"one", "two", "three"},
+	"dom":        []string{"example", "com"},
+	"dub":        "me/too",
+	"hello":      "Hello World!",
+	"half":       "50%",
+	"var":        "value",
+	"who":        "fred",
+	"base":       "http://example.com/home/",
+	"path":       "/foo/bar",
+	"list":       []string{"red", "green", "blue"},
+	"keys":       []rapi.URIPair{{Key: "semi", Value: ";"}, {Key: "dot", Value: "."}, {Key: "comma", Value: ","}},
+	"v":          6,
+	"x":          1024,
+	"y":          768,
+	"empty":      "",
+	"empty_keys": []rapi.URIPair{},
+	"undef":      nil,
+}
+
+// The examples of RFC 6570, section 3.2, and their expected expansion.
+var rfc6570Examples = [][2]string{
+	// Section 3.2.1: Variable Expansion.
+	{"{count}", "one,two,three"},
+	{"{count*}", "one,two,three"},
+	{"{/count}", "/one,two,three"},
+	{"{/count*}", "/one/two/three"},
+	{"{;count}", ";count=one,two,three"},
+	{"{;count*}", ";count=one;count=two;count=three"},
+	{"{?count}", "?count=one,two,three"},
+	{"{?count*}", "?count=one&count=two&count=three"},
+	{"{&count*}", "&count=one&count=two&count=three"},
+
+	// Section 3.2.2: Simple String Expansion.
+	{"{var}", "value"},
+	{"{hello}", "Hello%20World%21"},
+	{"{half}", "50%25"},
+	{"O{empty}X", "OX"},
+	{"O{undef}X", "OX"},
+	{"{x,y}", "1024,768"},
+	{"{x,hello,y}", "1024,Hello%20World%21,768"},
+	{"?{x,empty}", "?1024,"},
+	{"?{x,undef}", "?1024"},
+	{"?{undef,y}", "?768"},
+	{"{var:3}", "val"},
+	{"{var:30}", "value"},
+	{"{list}", "red,green,blue"},
+	{"{list*}", "red,green,blue"},
+	{"{keys}", "semi,%3B,dot,.,comma,%2C"},
+	{"{keys*}", "semi=%3B,dot=.,comma=%2C"},
+
+	// Section 3.2.3: Reserved Expansion.
+	{"{+var}", "value"},
+	{"{+hello}", "Hello%20World!"},
+	{"{+half}", "50%25"},
+	{"{base}index", "http%3A%2F%2Fexample.com%2Fhome%2Findex"},
+	{"{+base}index", "http://example.com/home/index"},
+	{"O{+empty}X", "OX"},
+	{"O{+undef}X", "OX"},
+	{"{+path}/here", "/foo/bar/here"},
+	{"here?ref={+path}", "here?ref=/foo/bar"},
+	{"up{+path}{var}/here", "up/foo/barvalue/here"},
+	{"{+x,hello,y}", "1024,Hello%20World!,768"},
+	{"{+path,x}/here", "/foo/bar,1024/here"},
+	{"{+path:6}/here", "/foo/b/here"},
+	{"{+list}", "red,green,blue"},
+	{"{+list*}", "red,green,blue"},
+	{"{+keys}", "semi,;,dot,.,comma,,"},
+	{"{+keys*}", "semi=;,dot=.,comma=,"},
+
+	// Section 3.2.4: Fragment Expansion.
+	{"{#var}", "#value"},
+	{"{#hello}", "#Hello%20World!"},
+	{"{#half}", "#50%25"},
+	{"foo{#empty}", "foo#"},
+	{"foo{#undef}", "foo"},
+	{"{#x,hello,y}", "#1024,Hello%20World!,768"},
+	{"{#path,x}/here", "#/foo/bar,1024/here"},
+	{"{#path:6}/here", "#/foo/b/here"},
+	{"{#list}", "#red,green,blue"},
+	{"{#list*}", "#red,green,blue"},
+	{"{#keys}", "#semi,;,dot,.,comma,,"},
+	{"{#keys*}", "#semi=;,dot=.,comma=,"},
+
+	// Section 3.2.5: Label Expansion with Dot-Prefix.
+	{"{.who}", ".fred"},
+	{"{.who,who}", ".fred.fred"},
+	{"{.half,who}", ".50%25.fred"},
+	{"www{.dom*}", "www.example.com"},
+	{"X{.var}", "X.value"},
+	{"X{.empty}", "X."},
+	{"X{.undef}", "X"},
+	{"X{.var:3}", "X.val"},
+	{"X{.list}", "X.red,green,blue"},
+	{"X{.list*}", "X.red.green.blue"},
+	{"X{.keys}", "X.semi,%3B,dot,.,comma,%2C"},
+	{"X{.keys*}", "X.semi=%3B.dot=..comma=%2C"},
+	{"X{.empty_keys}", "X"},
+	{"X{.empty_keys*}", "X"},
+
+	// Section 3.2.6: Path Segment Expansion.
+	{"{/who}", "/fred"},
+	{"{/who,who}", "/fred/fred"},
+	{"{/half,who}", "/50%25/fred"},
+	{"{/who,dub}", "/fred/me%2Ftoo"},
+	{"{/var}", "/value"},
+	{"{/var,empty}", "/value/"},
+	{"{/var,undef}", "/value"},
+	{"{/var,x}/here", "/value/1024/here"},
+	{"{/var:1,var}", "/v/value"},
+	{"{/list}", "/red,green,blue"},
+	{"{/list*}", "/red/green/blue"},
+	{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+	{"{/keys}", "/semi,%3B,dot,.,comma,%2C"},
+	{"{/keys*}", "/semi=%3B/dot=./comma=%2C"},
+
+	// Section 3.2.7: Path-Style Parameter Expansion.
+	{"{;who}", ";who=fred"},
+	{"{;half}", ";half=50%25"},
+	{"{;empty}", ";empty"},
+	{"{;v,empty,who}", ";v=6;empty;who=fred"},
+	{"{;v,bar,who}", ";v=6;who=fred"},
+	{"{;x,y}", ";x=1024;y=768"},
+	{"{;x,y,empty}", ";x=1024;y=768;empty"},
+	{"{;x,y,undef}", ";x=1024;y=768"},
+	{"{;hello:5}", ";hello=Hello"},
+	{"{;list}", ";list=red,green,blue"},
+	{"{;list*}", ";list=red;list=green;list=blue"},
+	{"{;keys}", ";keys=semi,%3B,dot,.,comma,%2C"},
+	{"{;keys*}", ";semi=%3B;dot=.;comma=%2C"},
+
+	// Section 3.2.8: Form-Style Query Expansion.
+	{"{?who}", "?who=fred"},
+	{"{?half}", "?half=50%25"},
+	{"{?x,y}", "?x=1024&y=768"},
+	{"{?x,y,empty}", "?x=1024&y=768&empty="},
+	{"{?x,y,undef}", "?x=1024&y=768"},
+	{"{?var:3}", "?var=val"},
+	{"{?list}", "?list=red,green,blue"},
+	{"{?list*}", "?list=red&list=green&list=blue"},
+	{"{?keys}", "?keys=semi,%3B,dot,.,comma,%2C"},
+	{"{?keys*}", "?semi=%3B&dot=.&comma=%2C"},
+
+	// Section 3.2.9: Form-Style Query Continuation.
+	{"{&who}", "&who=fred"},
+	{"{&half}", "&half=50%25"},
+	{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+	{"{&x,y,empty}", "&x=1024&y=768&empty="},
+	{"{&var:3}", "&var=val"},
+	{"{&list}", "&list=red,green,blue"},
+	{"{&list*}", "&list=red&list=green&list=blue"},
+	{"{&keys}", "&keys=semi,%3B,dot,.,comma,%2C"},
+	{"{&keys*}", "&semi=%3B&dot=.&comma=%2C"},
+}
+
+// UT: Expand a URI template.
+func TestExpandURITemplate(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for _, example := range rfc6570Examples {
+		t.Run("When the template is "+example[0]+".", func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// ACT.
+			got, err := rapi.ExpandURITemplate(example[0], rfc6570Vars)
+
+			// ASSERT.
+			assert.Nilf(t, err, "\n\n"+
+				"UT Name:  NO 'error' is returned when the template is valid.\n"+
+				"\033[32mExpected: <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", err)
+
+			assert.Equalf(t, got, example[1], "\n\n"+
+				"UT Name:  The template is expanded as described in RFC 6570.\n"+
+				"\033[32mExpected: %s\033[0m\n"+
+				"\033[31mActual:   %s\033[0m\n\n", example[1], got)
+		})
+	}
+
+	for _, template := range []string{"{var", "var}", "{=var}", "{var:0}", "{var:3*}", "{list:3}", "{}"} {
+		t.Run("When the template "+template+" is malformed.", func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// ACT.
+			_, err := rapi.ExpandURITemplate(template, rfc6570Vars)
+
+			// ASSERT.
+			assert.NotNilf(t, err, "\n\n"+
+				"UT Name:  An 'error' is returned when the template is malformed.\n"+
+				"\033[32mExpected: NOT <nil>\033[0m\n"+
+				"\033[31mActual:   %v\033[0m\n\n", err)
+		})
+	}
+}
+
+// UT: Make an HTTP request to an endpoint expanded from a URI template.
+func TestExpandEndpoint(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// FAKE SETUP.
+	srvFake := tstsrv.New(map[string]tstsrv.RespConfiguration{
+		"/repos/go-essentials/rapi/issues?state=open&labels=bug&labels=ui": {
+			Responses: []tstsrv.Response{
+				{StatusCode: http.StatusOK, Body: "[]"},
+			},
+		},
+	})
+
+	defer srvFake.Close()
+
+	// ARRANGE.
+	var got []any
+
+	request := rapi.GETRequestMsg{BaseRequest: rapi.BaseRequest{OkStatusCode: http.StatusOK}}
+
+	// ACT.
+	err := request.ExpandEndpoint("{+base}/repos{/owner,repo}/issues{?state,labels*}", map[string]any{
+		"base":   srvFake.URL(),
+		"owner":  "go-essentials",
+		"repo":   "rapi",
+		"state":  "open",
+		"labels": []string{"bug", "ui"},
+	})
+
+	if err == nil {
+		err = request.GET(http.DefaultClient, &got)
+	}
+
+	// ASSERT.
+	assert.Nilf(t, err, "\n\n"+
+		"UT Name:  NO 'error' is returned when the endpoint is expanded from a valid template.\n"+
+		"\033[32mExpected: <nil>\033[0m\n"+
+		"\033[31mActual:   %v\033[0m\n\n", err)
+}